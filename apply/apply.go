@@ -0,0 +1,134 @@
+// Package apply applies text edits and workspace edits the way a client
+// does.
+//
+// It lets tests, command line tools and servers previewing changes see the
+// result of the edits they send without an editor.
+package apply
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// RangeError reports an edit whose range is not inside the document.
+type RangeError struct {
+	// Index is the index of the edit in the edits passed to Edits.
+	Index int
+	// Range is the range of the edit.
+	Range domain.Range
+	// Err describes why the range is invalid.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("edit %d: invalid range %s: %v",
+		e.Index, formatRange(e.Range), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+// OverlapError reports two edits with overlapping ranges.
+type OverlapError struct {
+	// First and Second are the indexes of the edits in the edits passed to
+	// Edits with First starting before Second.
+	First, Second int
+	// FirstRange and SecondRange are the ranges of the edits.
+	FirstRange, SecondRange domain.Range
+}
+
+// Error implements the error interface.
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("edit %d at %s overlaps edit %d at %s",
+		e.First, formatRange(e.FirstRange),
+		e.Second, formatRange(e.SecondRange))
+}
+
+// Edits applies the edits to text and returns the new text.
+//
+// All ranges refer to the original text and are counted in the encoding.
+// The edits may be given in any order but must not overlap. Insertions at
+// the same position are applied in the order of the edits, as the
+// specification requires, and before a range starting at that position.
+// The text is left unchanged if an error is returned.
+func Edits(
+	text string,
+	edits []domain.TextEdit,
+	enc domain.PositionEncodingKind,
+) (string, error) {
+	type span struct {
+		index, start, end int
+	}
+	spans := make([]span, len(edits))
+	for i, edit := range edits {
+		start, err := domain.OffsetAt(text, edit.Range.Start, enc)
+		if err != nil {
+			return text, &RangeError{Index: i, Range: edit.Range, Err: err}
+		}
+		end, err := domain.OffsetAt(text, edit.Range.End, enc)
+		if err != nil {
+			return text, &RangeError{Index: i, Range: edit.Range, Err: err}
+		}
+		if end < start {
+			return text, &RangeError{
+				Index: i,
+				Range: edit.Range,
+				Err:   fmt.Errorf("end is before start"),
+			}
+		}
+		spans[i] = span{index: i, start: start, end: end}
+	}
+	// Insertions come before a range starting at the same offset so the
+	// range does not appear to overlap them.
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].start == spans[i].end && spans[j].start != spans[j].end
+	})
+	var b strings.Builder
+	b.Grow(len(text))
+	last := 0
+	for i, s := range spans {
+		if i > 0 && s.start < spans[i-1].end {
+			prev := spans[i-1]
+			return text, &OverlapError{
+				First:       prev.index,
+				Second:      s.index,
+				FirstRange:  edits[prev.index].Range,
+				SecondRange: edits[s.index].Range,
+			}
+		}
+		b.WriteString(text[last:s.start])
+		b.WriteString(edits[s.index].NewText)
+		last = s.end
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+// AnnotatedEdits applies annotated edits to text like Edits ignoring their
+// annotations.
+func AnnotatedEdits(
+	text string,
+	edits []domain.AnnotatedTextEdit,
+	enc domain.PositionEncodingKind,
+) (string, error) {
+	plain := make([]domain.TextEdit, len(edits))
+	for i, edit := range edits {
+		plain[i] = edit.TextEdit
+	}
+	return Edits(text, plain, enc)
+}
+
+// formatRange formats the range as `line:character-line:character`.
+func formatRange(r domain.Range) string {
+	return fmt.Sprintf("%d:%d-%d:%d",
+		r.Start.Line, r.Start.Character, r.End.Line, r.End.Character)
+}
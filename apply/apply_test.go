@@ -0,0 +1,139 @@
+package apply
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// edit creates an edit replacing the range from line:start to line:end.
+func edit(line, start, end int, text string) domain.TextEdit {
+	return domain.TextEdit{
+		Range: domain.Range{
+			Start: domain.Position{Line: line, Character: start},
+			End:   domain.Position{Line: line, Character: end},
+		},
+		NewText: text,
+	}
+}
+
+func TestEdits(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		edits   []domain.TextEdit
+		enc     domain.PositionEncodingKind
+		want    string
+		overlap bool
+		invalid bool
+	}{
+		{name: "no edits", text: "abc", want: "abc"},
+		{
+			name:  "unordered edits",
+			text:  "abc",
+			edits: []domain.TextEdit{edit(0, 2, 3, "C"), edit(0, 0, 1, "A")},
+			want:  "AbC",
+		},
+		{
+			name:  "insertions keep their order",
+			text:  "ab",
+			edits: []domain.TextEdit{edit(0, 1, 1, "1"), edit(0, 1, 1, "2")},
+			want:  "a12b",
+		},
+		{
+			name:  "insertion before a range at the same offset",
+			text:  "abc",
+			edits: []domain.TextEdit{edit(0, 1, 2, "B"), edit(0, 1, 1, "+")},
+			want:  "a+Bc",
+		},
+		{
+			name:  "insertion after a range ending at the offset",
+			text:  "abc",
+			edits: []domain.TextEdit{edit(0, 1, 1, "+"), edit(0, 0, 1, "A")},
+			want:  "A+bc",
+		},
+		{
+			name:  "adjacent ranges",
+			text:  "abc",
+			edits: []domain.TextEdit{edit(0, 1, 2, "B"), edit(0, 0, 1, "A")},
+			want:  "ABc",
+		},
+		{
+			name:  "multiple lines",
+			text:  "a\nb\n",
+			edits: []domain.TextEdit{edit(1, 0, 1, "B"), edit(0, 1, 1, "!")},
+			want:  "a!\nB\n",
+		},
+		{
+			name:  "utf-16 surrogate pairs",
+			text:  "😀x",
+			edits: []domain.TextEdit{edit(0, 2, 3, "y")},
+			enc:   domain.PositionEncodingUTF16,
+			want:  "😀y",
+		},
+		{
+			name:  "utf-8 bytes",
+			text:  "éx",
+			edits: []domain.TextEdit{edit(0, 2, 3, "y")},
+			enc:   domain.PositionEncodingUTF8,
+			want:  "éy",
+		},
+		{
+			name:  "character past the line end is clamped",
+			text:  "ab\ncd",
+			edits: []domain.TextEdit{edit(0, 1, 99, "")},
+			want:  "a\ncd",
+		},
+		{
+			name:    "overlapping ranges",
+			text:    "abc",
+			edits:   []domain.TextEdit{edit(0, 0, 2, ""), edit(0, 1, 3, "")},
+			overlap: true,
+		},
+		{
+			name:    "insertion inside a range",
+			text:    "abc",
+			edits:   []domain.TextEdit{edit(0, 0, 2, ""), edit(0, 1, 1, "+")},
+			overlap: true,
+		},
+		{
+			name:    "line past the end",
+			text:    "abc",
+			edits:   []domain.TextEdit{edit(3, 0, 0, "x")},
+			invalid: true,
+		},
+		{
+			name: "end before start",
+			text: "abc",
+			edits: []domain.TextEdit{{Range: domain.Range{
+				Start: domain.Position{Character: 2},
+				End:   domain.Position{Character: 1},
+			}}},
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := tt.enc
+			if enc == "" {
+				enc = domain.PositionEncodingUTF16
+			}
+			got, err := Edits(tt.text, tt.edits, enc)
+			var overlap *OverlapError
+			var invalid *RangeError
+			if errors.As(err, &overlap) != tt.overlap || errors.As(err, &invalid) != tt.invalid {
+				t.Fatalf("err = %v, want overlap %v, invalid range %v", err, tt.overlap, tt.invalid)
+			}
+			if err != nil {
+				if got != tt.text {
+					t.Errorf("text changed on error: %q", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
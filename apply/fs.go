@@ -0,0 +1,388 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// FS is a file system addressed by document uris which workspace edits
+// are applied to.
+type FS interface {
+	// ReadFile returns the content of the file. The error wraps
+	// fs.ErrNotExist if the file does not exist.
+	ReadFile(uri string) (string, error)
+	// WriteFile creates or replaces the file.
+	WriteFile(uri string, text string) error
+	// Exists reports whether a file or folder exists at the uri.
+	Exists(uri string) (bool, error)
+	// Rename moves the file or folder at oldURI to newURI replacing an
+	// existing file.
+	Rename(oldURI, newURI string) error
+	// Remove deletes the file or folder. Folders which are not empty are
+	// only deleted if recursive is set.
+	Remove(uri string, recursive bool) error
+}
+
+// ChangeError reports the change of a workspace edit which failed to
+// apply.
+//
+// All changes before it were applied and are kept.
+type ChangeError struct {
+	// Index is the index of the change in the document changes of the
+	// edit, or in the sorted uris of its changes map.
+	Index int
+	// URI is the uri of the document or file of the change.
+	URI string
+	// Err is the reason the change failed.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ChangeError) Error() string {
+	return fmt.Sprintf("change %d of %s: %v", e.Index, e.URI, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
+
+// Workspace applies the workspace edit to the file system.
+//
+// Document changes are applied in order and take precedence over the
+// changes map like clients do. The versions of text document edits are not
+// checked as the file system has none.
+//
+// Applying is not atomic since FS cannot list or snapshot folders.
+// Applying stops at the first failing change which is reported as a
+// *ChangeError; the changes before it stay applied. Apply the edit to a
+// MapFS copy first to check it without touching the file system.
+func Workspace(
+	fsys FS,
+	edit domain.WorkspaceEdit,
+	enc domain.PositionEncodingKind,
+) error {
+	if len(edit.DocumentChanges) > 0 {
+		for i, change := range edit.DocumentChanges {
+			if err := documentChange(fsys, change, enc); err != nil {
+				return &ChangeError{Index: i, URI: changeURI(change), Err: err}
+			}
+		}
+		return nil
+	}
+	uris := make([]string, 0, len(edit.Changes))
+	for uri := range edit.Changes {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for i, uri := range uris {
+		if err := textEdits(fsys, uri, edit.Changes[uri], enc); err != nil {
+			return &ChangeError{Index: i, URI: uri, Err: err}
+		}
+	}
+	return nil
+}
+
+// changeURI returns the uri of the document or file changed by the
+// change.
+func changeURI(change domain.DocumentChange) string {
+	switch {
+	case change.TextDocumentEdit != nil:
+		return change.TextDocumentEdit.TextDocument.URI
+	case change.CreateFile != nil:
+		return change.CreateFile.URI
+	case change.RenameFile != nil:
+		return change.RenameFile.OldURI
+	case change.DeleteFile != nil:
+		return change.DeleteFile.URI
+	}
+	return ""
+}
+
+// documentChange applies a single document change to the file system.
+func documentChange(
+	fsys FS,
+	change domain.DocumentChange,
+	enc domain.PositionEncodingKind,
+) error {
+	switch {
+	case change.TextDocumentEdit != nil:
+		edit := change.TextDocumentEdit
+		edits := make([]domain.TextEdit, len(edit.Edits))
+		for i, annotated := range edit.Edits {
+			edits[i] = annotated.TextEdit
+		}
+		return textEdits(fsys, edit.TextDocument.URI, edits, enc)
+	case change.CreateFile != nil:
+		return createFile(fsys, change.CreateFile)
+	case change.RenameFile != nil:
+		return renameFile(fsys, change.RenameFile)
+	case change.DeleteFile != nil:
+		return deleteFile(fsys, change.DeleteFile)
+	}
+	return errors.New("empty document change")
+}
+
+// textEdits applies the edits to the file at uri.
+func textEdits(
+	fsys FS,
+	uri string,
+	edits []domain.TextEdit,
+	enc domain.PositionEncodingKind,
+) error {
+	text, err := fsys.ReadFile(uri)
+	if err != nil {
+		return err
+	}
+	text, err = Edits(text, edits, enc)
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFile(uri, text)
+}
+
+// createFile applies a create file operation.
+func createFile(fsys FS, op *domain.CreateFile) error {
+	exists, err := fsys.Exists(op.URI)
+	if err != nil {
+		return err
+	}
+	if exists {
+		options := domain.CreateFileOptions{}
+		if op.Options != nil {
+			options = *op.Options
+		}
+		switch {
+		case options.Overwrite:
+		case options.IgnoreIfExists:
+			return nil
+		default:
+			return fmt.Errorf("create %s: %w", op.URI, fs.ErrExist)
+		}
+	}
+	return fsys.WriteFile(op.URI, "")
+}
+
+// renameFile applies a rename file operation.
+func renameFile(fsys FS, op *domain.RenameFile) error {
+	exists, err := fsys.Exists(op.NewURI)
+	if err != nil {
+		return err
+	}
+	if exists {
+		options := domain.RenameFileOptions{}
+		if op.Options != nil {
+			options = *op.Options
+		}
+		switch {
+		case options.Overwrite:
+		case options.IgnoreIfExists:
+			return nil
+		default:
+			return fmt.Errorf("rename %s to %s: %w",
+				op.OldURI, op.NewURI, fs.ErrExist)
+		}
+	}
+	return fsys.Rename(op.OldURI, op.NewURI)
+}
+
+// deleteFile applies a delete file operation.
+func deleteFile(fsys FS, op *domain.DeleteFile) error {
+	options := domain.DeleteFileOptions{}
+	if op.Options != nil {
+		options = *op.Options
+	}
+	exists, err := fsys.Exists(op.URI)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if options.IgnoreIfNotExists {
+			return nil
+		}
+		return fmt.Errorf("delete %s: %w", op.URI, fs.ErrNotExist)
+	}
+	return fsys.Remove(op.URI, options.Recursive)
+}
+
+// MapFS is an in-memory file system mapping document uris to the contents
+// of files. Folders exist implicitly as the prefixes of the uris of their
+// files.
+type MapFS map[string]string
+
+// Clone returns a copy of the file system, e.g. to stage a workspace edit
+// before applying it to the real file system.
+func (m MapFS) Clone() MapFS {
+	clone := make(MapFS, len(m))
+	for uri, text := range m {
+		clone[uri] = text
+	}
+	return clone
+}
+
+// ReadFile implements FS.
+func (m MapFS) ReadFile(uri string) (string, error) {
+	text, ok := m[uri]
+	if !ok {
+		return "", fmt.Errorf("read %s: %w", uri, fs.ErrNotExist)
+	}
+	return text, nil
+}
+
+// WriteFile implements FS.
+func (m MapFS) WriteFile(uri string, text string) error {
+	m[uri] = text
+	return nil
+}
+
+// Exists implements FS.
+func (m MapFS) Exists(uri string) (bool, error) {
+	if _, ok := m[uri]; ok {
+		return true, nil
+	}
+	return len(m.children(uri)) > 0, nil
+}
+
+// Rename implements FS.
+func (m MapFS) Rename(oldURI, newURI string) error {
+	if text, ok := m[oldURI]; ok {
+		delete(m, oldURI)
+		m[newURI] = text
+		return nil
+	}
+	children := m.children(oldURI)
+	if len(children) == 0 {
+		return fmt.Errorf("rename %s: %w", oldURI, fs.ErrNotExist)
+	}
+	for _, child := range children {
+		text := m[child]
+		delete(m, child)
+		m[newURI+strings.TrimPrefix(child, oldURI)] = text
+	}
+	return nil
+}
+
+// Remove implements FS.
+func (m MapFS) Remove(uri string, recursive bool) error {
+	if _, ok := m[uri]; ok {
+		delete(m, uri)
+		return nil
+	}
+	children := m.children(uri)
+	if len(children) == 0 {
+		return fmt.Errorf("remove %s: %w", uri, fs.ErrNotExist)
+	}
+	if !recursive {
+		return fmt.Errorf("remove %s: folder is not empty", uri)
+	}
+	for _, child := range children {
+		delete(m, child)
+	}
+	return nil
+}
+
+// children returns the uris of the files in the folder at uri.
+func (m MapFS) children(uri string) []string {
+	prefix := strings.TrimSuffix(uri, "/") + "/"
+	var children []string
+	for child := range m {
+		if strings.HasPrefix(child, prefix) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// DiskFS is the file system of the operating system addressed by `file`
+// uris.
+type DiskFS struct{}
+
+// ReadFile implements FS.
+func (DiskFS) ReadFile(uri string) (string, error) {
+	path, err := Path(uri)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// WriteFile implements FS.
+//
+// Missing parent folders are created.
+func (DiskFS) WriteFile(uri string, text string) error {
+	path, err := Path(uri)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0o644)
+}
+
+// Exists implements FS.
+func (DiskFS) Exists(uri string) (bool, error) {
+	path, err := Path(uri)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Rename implements FS.
+func (DiskFS) Rename(oldURI, newURI string) error {
+	oldPath, err := Path(oldURI)
+	if err != nil {
+		return err
+	}
+	newPath, err := Path(newURI)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+// Remove implements FS.
+func (DiskFS) Remove(uri string, recursive bool) error {
+	path, err := Path(uri)
+	if err != nil {
+		return err
+	}
+	if recursive {
+		return os.RemoveAll(path)
+	}
+	return os.Remove(path)
+}
+
+// Path returns the file system path of a `file` uri.
+func Path(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("%s is not a file uri", uri)
+	}
+	path := u.Path
+	// Windows paths are written as `file:///C:/dir/file`.
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), nil
+}
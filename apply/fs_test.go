@@ -0,0 +1,151 @@
+package apply
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// textDocumentEdit creates a document change applying the edits to uri.
+func textDocumentEdit(uri string, edits ...domain.TextEdit) domain.DocumentChange {
+	annotated := make([]domain.AnnotatedTextEdit, len(edits))
+	for i, edit := range edits {
+		annotated[i] = domain.AnnotatedTextEdit{TextEdit: edit}
+	}
+	return domain.DocumentChange{TextDocumentEdit: &domain.TextDocumentEdit{
+		TextDocument: domain.OptionalVersionedTextDocumentIdentifier{URI: uri},
+		Edits:        annotated,
+	}}
+}
+
+func TestWorkspace(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   MapFS
+		edit    domain.WorkspaceEdit
+		want    MapFS
+		wantErr error
+		index   int
+	}{
+		{
+			name:  "changes map",
+			files: MapFS{"a": "x", "b": "y"},
+			edit: domain.WorkspaceEdit{Changes: map[string][]domain.TextEdit{
+				"a": {edit(0, 0, 1, "X")},
+				"b": {edit(0, 1, 1, "!")},
+			}},
+			want: MapFS{"a": "X", "b": "y!"},
+		},
+		{
+			name:  "document changes in order",
+			files: MapFS{"a": "x"},
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				{CreateFile: &domain.CreateFile{URI: "b"}},
+				textDocumentEdit("b", edit(0, 0, 0, "new")),
+				{RenameFile: &domain.RenameFile{OldURI: "a", NewURI: "c"}},
+				textDocumentEdit("c", edit(0, 0, 1, "moved")),
+			}},
+			want: MapFS{"b": "new", "c": "moved"},
+		},
+		{
+			name:  "rename and delete folders",
+			files: MapFS{"d/a": "1", "d/b": "2", "e/c": "3"},
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				{RenameFile: &domain.RenameFile{OldURI: "d", NewURI: "f"}},
+				{DeleteFile: &domain.DeleteFile{
+					URI:     "e",
+					Options: &domain.DeleteFileOptions{Recursive: true},
+				}},
+			}},
+			want: MapFS{"f/a": "1", "f/b": "2"},
+		},
+		{
+			name:  "ignored existing file",
+			files: MapFS{"a": "x"},
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				{CreateFile: &domain.CreateFile{
+					URI:     "a",
+					Options: &domain.CreateFileOptions{IgnoreIfExists: true},
+				}},
+			}},
+			want: MapFS{"a": "x"},
+		},
+		{
+			name:  "create existing file",
+			files: MapFS{"a": "x"},
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				textDocumentEdit("a", edit(0, 0, 1, "y")),
+				{CreateFile: &domain.CreateFile{URI: "a"}},
+			}},
+			want:    MapFS{"a": "y"},
+			wantErr: fs.ErrExist,
+			index:   1,
+		},
+		{
+			name:  "delete missing file",
+			files: MapFS{},
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				{DeleteFile: &domain.DeleteFile{URI: "a"}},
+			}},
+			want:    MapFS{},
+			wantErr: fs.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Workspace(tt.files, tt.edit, domain.PositionEncodingUTF16)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var change *ChangeError
+			if err != nil && (!errors.As(err, &change) || change.Index != tt.index) {
+				t.Errorf("err = %#v, want change error at %d", err, tt.index)
+			}
+			if !reflect.DeepEqual(tt.files, tt.want) {
+				t.Errorf("files = %v, want %v", tt.files, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiskFS(t *testing.T) {
+	dir := t.TempDir()
+	uri := func(name string) string {
+		return "file://" + filepath.ToSlash(filepath.Join(dir, name))
+	}
+	err := Workspace(DiskFS{}, domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+		{CreateFile: &domain.CreateFile{URI: uri("sub/a.txt")}},
+		textDocumentEdit(uri("sub/a.txt"), edit(0, 0, 0, "hello")),
+		{RenameFile: &domain.RenameFile{OldURI: uri("sub"), NewURI: uri("moved")}},
+	}}, domain.PositionEncodingUTF16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "moved", "a.txt"))
+	if err != nil || string(data) != "hello" {
+		t.Errorf("moved file = %q, %v, want hello", data, err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    string
+		wantErr bool
+	}{
+		{"file:///tmp/a%20b.go", filepath.FromSlash("/tmp/a b.go"), false},
+		{"file:///C:/dir/file", filepath.FromSlash("C:/dir/file"), false},
+		{"untitled:Untitled-1", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Path(tt.uri)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Path(%q) = %q, %v, want %q", tt.uri, got, err, tt.want)
+		}
+	}
+}
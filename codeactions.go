@@ -0,0 +1,190 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// QuickFixFunc computes the quick fixes for a diagnostic in the context of
+// a code action request.
+type QuickFixFunc func(
+	ctx context.Context,
+	params domain.TextDocumentCodeActionParams,
+	diagnostic domain.Diagnostic,
+) ([]domain.CodeAction, error)
+
+// CodeActionResolveFunc computes the missing properties, usually the edit,
+// of a code action.
+type CodeActionResolveFunc func(
+	ctx context.Context,
+	action domain.CodeAction,
+) (domain.CodeAction, error)
+
+// CodeActions routes code action requests to quick fix providers keyed by
+// the source and code of the diagnostics in the request context.
+type CodeActions struct {
+	providers *registry[quickFixProvider]
+}
+
+// quickFixKey identifies the quick fix provider of diagnostics since the
+// same code may be used by different sources.
+type quickFixKey struct {
+	Source string                `json:"source,omitempty"`
+	Code   domain.DiagnosticCode `json:"code"`
+}
+
+// String returns the registry key of the provider.
+func (k quickFixKey) String() string {
+	encoded, err := json.Marshal(k)
+	if err != nil {
+		// A string and a string or integer code always encode.
+		panic(err)
+	}
+	return string(encoded)
+}
+
+// quickFixProvider is the quick fix provider of a diagnostic source and
+// code with the resolver of its quick fixes.
+type quickFixProvider struct {
+	fix     QuickFixFunc
+	resolve CodeActionResolveFunc
+}
+
+// NewCodeActions creates a new empty code action registry.
+func NewCodeActions() *CodeActions {
+	return &CodeActions{
+		providers: newRegistry(func(provider quickFixProvider) bool {
+			return provider.resolve != nil
+		}),
+	}
+}
+
+// HandleQuickFix registers the quick fix provider for diagnostics with the
+// given source and code.
+func (c *CodeActions) HandleQuickFix(
+	source string,
+	code domain.DiagnosticCode,
+	fix QuickFixFunc,
+) {
+	key := quickFixKey{Source: source, Code: code}
+	c.providers.handle(key.String(), func(provider *quickFixProvider) {
+		provider.fix = fix
+	})
+}
+
+// HandleResolve registers the resolver for the quick fixes of diagnostics
+// with the given source and code.
+//
+// The quick fixes can then omit their edit which is computed by resolve
+// once the client asks for it.
+func (c *CodeActions) HandleResolve(
+	source string,
+	code domain.DiagnosticCode,
+	resolve CodeActionResolveFunc,
+) {
+	key := quickFixKey{Source: source, Code: code}
+	c.providers.handle(key.String(), func(provider *quickFixProvider) {
+		provider.resolve = resolve
+	})
+}
+
+// Options returns the code action server capabilities of the registry.
+func (c *CodeActions) Options() domain.CodeActionOptions {
+	return domain.CodeActionOptions{
+		CodeActionKinds: []domain.CodeActionKind{domain.CodeActionKindQuickFix},
+		ResolveProvider: c.providers.resolveProvider(),
+	}
+}
+
+// CodeActions answers a code action request by calling the quick fix
+// provider of every diagnostic in the request context.
+//
+// Quick fixes default to the quickfix kind and to resolving the diagnostic
+// they were computed for. No provider is called if the requested kinds
+// exclude quick fixes, and actions not matching the requested kinds are
+// dropped.
+func (c *CodeActions) CodeActions(
+	ctx context.Context,
+	params domain.TextDocumentCodeActionParams,
+) ([]domain.CodeAction, error) {
+	actions := []domain.CodeAction{}
+	if !quickFixRequested(params.Context.Only) {
+		return actions, nil
+	}
+	for _, diagnostic := range params.Context.Diagnostics {
+		if diagnostic.Code == nil {
+			continue
+		}
+		key := quickFixKey{Source: diagnostic.Source, Code: *diagnostic.Code}.String()
+		provider, ok := c.providers.lookup(key)
+		if !ok || provider.fix == nil {
+			continue
+		}
+		fixes, err := provider.fix(ctx, params, diagnostic)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range fixes {
+			if action.Kind == domain.CodeActionKindEmpty {
+				action.Kind = domain.CodeActionKindQuickFix
+			}
+			if !kindRequested(params.Context.Only, action.Kind) {
+				continue
+			}
+			if len(action.Diagnostics) == 0 {
+				action.Diagnostics = []domain.Diagnostic{diagnostic}
+			}
+			if action.Data, err = c.providers.wrap(key, provider, action.Data); err != nil {
+				return nil, err
+			}
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+// Resolve answers a code action resolve request by calling the resolver
+// registered for the source and code of the diagnostic the action was
+// computed for.
+func (c *CodeActions) Resolve(
+	ctx context.Context,
+	action domain.CodeAction,
+) (domain.CodeAction, error) {
+	provider, data, ok := c.providers.unwrap(action.Data)
+	if !ok {
+		return action, nil
+	}
+	action.Data = data
+	return provider.resolve(ctx, action)
+}
+
+// quickFixRequested reports whether quick fixes or a sub kind of them may
+// be returned for the requested kinds.
+func quickFixRequested(only []domain.CodeActionKind) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, requested := range only {
+		if requested.Contains(domain.CodeActionKindQuickFix) ||
+			domain.CodeActionKindQuickFix.Contains(requested) {
+			return true
+		}
+	}
+	return false
+}
+
+// kindRequested reports whether kind is contained in one of the requested
+// kinds or if no kinds were requested.
+func kindRequested(only []domain.CodeActionKind, kind domain.CodeActionKind) bool {
+	if len(only) == 0 {
+		return true
+	}
+	for _, requested := range only {
+		if requested.Contains(kind) {
+			return true
+		}
+	}
+	return false
+}
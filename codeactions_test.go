@@ -0,0 +1,124 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestCodeActions(t *testing.T) {
+	code := domain.NewDiagnosticCode("unused")
+	called := map[string]int{}
+	fix := func(source string) QuickFixFunc {
+		return func(
+			_ context.Context,
+			_ domain.TextDocumentCodeActionParams,
+			_ domain.Diagnostic,
+		) ([]domain.CodeAction, error) {
+			called[source]++
+			return []domain.CodeAction{{Title: "fix " + source}}, nil
+		}
+	}
+	c := NewCodeActions()
+	c.HandleQuickFix("vet", code, fix("vet"))
+	c.HandleQuickFix("lint", code, fix("lint"))
+	resolve := func(
+		_ context.Context,
+		action domain.CodeAction,
+	) (domain.CodeAction, error) {
+		action.Title += " resolved"
+		return action, nil
+	}
+	c.HandleResolve("lint", code, resolve)
+	c.HandleResolve("orphan", code, resolve)
+	if !c.Options().ResolveProvider {
+		t.Error("registry with a resolver does not resolve actions")
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		only   []domain.CodeActionKind
+		want   []string
+		calls  int
+	}{
+		{"routes by source", "vet", nil, []string{"fix vet"}, 1},
+		{"same code other source", "lint", nil, []string{"fix lint"}, 1},
+		{"unknown source", "other", nil, nil, 0},
+		{"resolver without quick fix", "orphan", nil, nil, 0},
+		{"quickfix requested", "vet", []domain.CodeActionKind{domain.CodeActionKindQuickFix}, []string{"fix vet"}, 1},
+		{"quickfix excluded", "vet", []domain.CodeActionKind{domain.CodeActionKindSource}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = map[string]int{}
+			params := domain.TextDocumentCodeActionParams{}
+			params.Context.Only = tt.only
+			params.Context.Diagnostics = []domain.Diagnostic{{Source: tt.source, Code: &code}}
+			actions, err := c.CodeActions(context.Background(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var titles []string
+			for _, action := range actions {
+				titles = append(titles, action.Title)
+				if action.Kind != domain.CodeActionKindQuickFix {
+					t.Errorf("kind = %q, want quickfix", action.Kind)
+				}
+			}
+			if !equalStrings(titles, tt.want) {
+				t.Errorf("titles = %q, want %q", titles, tt.want)
+			}
+			if called[tt.source] != tt.calls {
+				t.Errorf("provider called %d times, want %d", called[tt.source], tt.calls)
+			}
+		})
+	}
+
+	params := domain.TextDocumentCodeActionParams{}
+	params.Context.Diagnostics = []domain.Diagnostic{{Source: "lint", Code: &code}}
+	actions, err := c.CodeActions(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := c.Resolve(context.Background(), actions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Title != "fix lint resolved" || resolved.Data != nil {
+		t.Errorf("resolved = %+v, want resolved title and unwrapped data", resolved)
+	}
+}
+
+func TestBoolOrCodeActionOptions(t *testing.T) {
+	tests := []struct {
+		provider domain.BoolOrCodeActionOptions
+		want     string
+	}{
+		{domain.BoolOrCodeActionOptions{}, `false`},
+		{domain.BoolOrCodeActionOptions{Enabled: true}, `true`},
+		{
+			domain.BoolOrCodeActionOptions{Options: &domain.CodeActionOptions{ResolveProvider: true}},
+			`{"resolveProvider":true}`,
+		},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.provider)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("marshal = %s, want %s", data, tt.want)
+		}
+		var decoded domain.BoolOrCodeActionOptions
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Enabled != (tt.provider.Enabled || tt.provider.Options != nil) ||
+			(decoded.Options == nil) != (tt.provider.Options == nil) {
+			t.Errorf("unmarshal %s = %+v", data, decoded)
+		}
+	}
+}
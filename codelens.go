@@ -0,0 +1,105 @@
+package glisp
+
+import (
+	"context"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// CodeLensFunc computes the code lenses of a document.
+//
+// Lenses without a command are resolved later by the resolver of the
+// provider, so providers return cheap unresolved lenses and compute their
+// commands only for the lenses the client shows.
+type CodeLensFunc func(
+	ctx context.Context,
+	params domain.CodeLensParams,
+) ([]domain.CodeLens, error)
+
+// CodeLensResolveFunc computes the command of a code lens.
+type CodeLensResolveFunc func(
+	ctx context.Context,
+	lens domain.CodeLens,
+) (domain.CodeLens, error)
+
+// codeLensProvider is a code lens provider registered by name.
+type codeLensProvider struct {
+	lenses  CodeLensFunc
+	resolve CodeLensResolveFunc
+}
+
+// CodeLenses routes code lens and code lens resolve requests to the
+// registered providers.
+type CodeLenses struct {
+	providers *registry[codeLensProvider]
+}
+
+// NewCodeLenses creates a new empty code lens registry.
+func NewCodeLenses() *CodeLenses {
+	return &CodeLenses{
+		providers: newRegistry(func(provider codeLensProvider) bool {
+			return provider.resolve != nil
+		}),
+	}
+}
+
+// Handle registers the code lens provider with the given name.
+//
+// resolve may be nil if the provider always returns resolved lenses.
+func (c *CodeLenses) Handle(
+	name string,
+	lenses CodeLensFunc,
+	resolve CodeLensResolveFunc,
+) {
+	c.providers.handle(name, func(provider *codeLensProvider) {
+		*provider = codeLensProvider{lenses: lenses, resolve: resolve}
+	})
+}
+
+// Options returns the code lens server capabilities of the registry.
+func (c *CodeLenses) Options() domain.CodeLensOptions {
+	return domain.CodeLensOptions{ResolveProvider: c.providers.resolveProvider()}
+}
+
+// CodeLenses answers a code lens request with the lenses of all providers
+// ordered by provider name.
+//
+// The data of unresolved lenses is wrapped so Resolve can route them back
+// to their provider.
+func (c *CodeLenses) CodeLenses(
+	ctx context.Context,
+	params domain.CodeLensParams,
+) ([]domain.CodeLens, error) {
+	names, providers := c.providers.sorted()
+	all := []domain.CodeLens{}
+	for i, provider := range providers {
+		lenses, err := provider.lenses(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, lens := range lenses {
+			if lens.Command == nil {
+				lens.Data, err = c.providers.wrap(names[i], provider, lens.Data)
+				if err != nil {
+					return nil, err
+				}
+			}
+			all = append(all, lens)
+		}
+	}
+	return all, nil
+}
+
+// Resolve answers a code lens resolve request by calling the resolver of
+// the provider of the lens.
+func (c *CodeLenses) Resolve(
+	ctx context.Context,
+	lens domain.CodeLens,
+) (domain.CodeLens, error) {
+	provider, data, ok := c.providers.unwrap(lens.Data)
+	if !ok {
+		return lens, nil
+	}
+	lens.Data = data
+	return provider.resolve(ctx, lens)
+}
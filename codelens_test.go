@@ -0,0 +1,77 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestCodeLenses(t *testing.T) {
+	lenses := func(titles ...string) CodeLensFunc {
+		return func(context.Context, domain.CodeLensParams) ([]domain.CodeLens, error) {
+			var lenses []domain.CodeLens
+			for _, title := range titles {
+				lens := domain.CodeLens{Data: json.RawMessage(`"` + title + `"`)}
+				if title == "resolved" {
+					lens.Command = &domain.Command{Title: title}
+				}
+				lenses = append(lenses, lens)
+			}
+			return lenses, nil
+		}
+	}
+	resolve := func(_ context.Context, lens domain.CodeLens) (domain.CodeLens, error) {
+		var title string
+		if err := json.Unmarshal(lens.Data, &title); err != nil {
+			return lens, err
+		}
+		lens.Command = &domain.Command{Title: title}
+		return lens, nil
+	}
+	c := NewCodeLenses()
+	if c.Options().ResolveProvider {
+		t.Error("empty registry resolves lenses")
+	}
+	c.Handle("b", lenses("b1", "resolved"), resolve)
+	c.Handle("a", lenses("a1"), nil)
+	if !c.Options().ResolveProvider {
+		t.Error("registry with a resolver does not resolve lenses")
+	}
+
+	got, err := c.CodeLenses(context.Background(), domain.CodeLensParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		data  string
+		title string
+	}{
+		{"provider without resolver keeps its data", `"a1"`, ""},
+		{"unresolved lens is wrapped", `{"glisp":"b","data":"b1"}`, "b1"},
+		{"resolved lens keeps its data", `"resolved"`, "resolved"},
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("got %d lenses, want %d", len(got), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if string(got[i].Data) != tt.data {
+				t.Errorf("data = %s, want %s", got[i].Data, tt.data)
+			}
+			resolved, err := c.Resolve(context.Background(), got[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			title := ""
+			if resolved.Command != nil {
+				title = resolved.Command.Title
+			}
+			if title != tt.title {
+				t.Errorf("resolved title = %q, want %q", title, tt.title)
+			}
+		})
+	}
+}
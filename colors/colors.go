@@ -0,0 +1,302 @@
+// Package colors detects color literals in the text of a document and
+// writes colors back in the same notations.
+//
+// Hex colors (`#rgb`, `#rgba`, `#rrggbb` and `#rrggbbaa`) and the CSS
+// functions `rgb()`, `rgba()`, `hsl()` and `hsla()` are recognized. Hex
+// colors are only recognized in a value position so CSS id selectors,
+// markdown headings and issue references are not mistaken for colors.
+package colors
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+var (
+	// hexPattern matches hex colors not preceded by a word character or
+	// an ampersand, e.g. of an HTML entity.
+	hexPattern = regexp.MustCompile(`(?:^|[^\w&])(#(?:[0-9a-fA-F]{8}|[0-9a-fA-F]{6}|[0-9a-fA-F]{3,4}))\b`)
+	// rgbPattern matches rgb() and rgba() with comma or space separated
+	// components.
+	rgbPattern = regexp.MustCompile(`(?i)\brgba?\(\s*([\d.]+%?)\s*[,\s]\s*([\d.]+%?)\s*[,\s]\s*([\d.]+%?)\s*(?:[,/]\s*([\d.]+%?)\s*)?\)`)
+	// hslPattern matches hsl() and hsla() with comma or space separated
+	// components.
+	hslPattern = regexp.MustCompile(`(?i)\bhsla?\(\s*([\d.]+)(?:deg)?\s*[,\s]\s*([\d.]+)%\s*[,\s]\s*([\d.]+)%\s*(?:[,/]\s*([\d.]+%?)\s*)?\)`)
+)
+
+// Detect returns the color literals of text ordered by position.
+//
+// The characters of the ranges are counted in the encoding. Literals with
+// components out of range are ignored.
+func Detect(text string, enc domain.PositionEncodingKind) []domain.ColorInformation {
+	lines := domain.NewLineIndex(text, enc)
+	found := []domain.ColorInformation{}
+	add := func(start, end int, color domain.Color, ok bool) {
+		if ok {
+			found = append(found, domain.ColorInformation{
+				Range: lines.Range(start, end),
+				Color: color,
+			})
+		}
+	}
+	for _, m := range hexPattern.FindAllStringSubmatchIndex(text, -1) {
+		if !valuePosition(text, m[2], m[3]) {
+			continue
+		}
+		color, ok := parseHex(text[m[2]+1 : m[3]])
+		add(m[2], m[3], color, ok)
+	}
+	for _, m := range rgbPattern.FindAllStringSubmatchIndex(text, -1) {
+		color, ok := parseRGB(groups(text, m))
+		add(m[0], m[1], color, ok)
+	}
+	for _, m := range hslPattern.FindAllStringSubmatchIndex(text, -1) {
+		color, ok := parseHSL(groups(text, m))
+		add(m[0], m[1], color, ok)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Range.Start.Compare(found[j].Range.Start) < 0
+	})
+	return found
+}
+
+// Presentations returns the ways to write the color replacing the range:
+// as a hex color, with rgb() and with hsl().
+func Presentations(color domain.Color, rng domain.Range) []domain.ColorPresentation {
+	labels := []string{Hex(color), RGB(color), HSL(color)}
+	presentations := make([]domain.ColorPresentation, len(labels))
+	for i, label := range labels {
+		presentations[i] = domain.ColorPresentation{
+			Label:    label,
+			TextEdit: &domain.TextEdit{Range: rng, NewText: label},
+		}
+	}
+	return presentations
+}
+
+// Hex writes the color as `#rrggbb` or `#rrggbbaa` if it is translucent.
+func Hex(color domain.Color) string {
+	hex := fmt.Sprintf("#%02x%02x%02x",
+		byteOf(color.Red), byteOf(color.Green), byteOf(color.Blue))
+	if color.Alpha < 1 {
+		hex += fmt.Sprintf("%02x", byteOf(color.Alpha))
+	}
+	return hex
+}
+
+// RGB writes the color as `rgb(r, g, b)` or `rgba(r, g, b, a)` if it is
+// translucent.
+func RGB(color domain.Color) string {
+	r, g, b := byteOf(color.Red), byteOf(color.Green), byteOf(color.Blue)
+	if color.Alpha < 1 {
+		return fmt.Sprintf("rgba(%d, %d, %d, %s)", r, g, b, formatAlpha(color.Alpha))
+	}
+	return fmt.Sprintf("rgb(%d, %d, %d)", r, g, b)
+}
+
+// HSL writes the color as `hsl(h, s%, l%)` or `hsla(h, s%, l%, a)` if it
+// is translucent.
+func HSL(color domain.Color) string {
+	h, s, l := toHSL(color)
+	hue := int(math.Round(h)) % 360
+	sat, light := int(math.Round(s*100)), int(math.Round(l*100))
+	if color.Alpha < 1 {
+		return fmt.Sprintf("hsla(%d, %d%%, %d%%, %s)", hue, sat, light, formatAlpha(color.Alpha))
+	}
+	return fmt.Sprintf("hsl(%d, %d%%, %d%%)", hue, sat, light)
+}
+
+// groups returns the texts of the four capture groups of a match, empty
+// for groups which did not participate.
+func groups(text string, m []int) [4]string {
+	var g [4]string
+	for i := range g {
+		if start := m[2+2*i]; start >= 0 {
+			g[i] = text[start:m[3+2*i]]
+		}
+	}
+	return g
+}
+
+// valuePosition reports whether the hex literal at text[start:end] is in
+// a position holding a color value: either quoted on its own, as in
+// `"#fff"`, or in the value of a declaration on the same line, as in
+// `color: #fff` or `bgcolor=#fff`.
+//
+// Literals in a CSS selector are not values since a `{` follows them
+// before the end of the declaration. A literal after a colon in prose,
+// e.g. `Fixes: #123`, cannot be told apart from a declaration and is
+// still detected.
+func valuePosition(text string, start, end int) bool {
+	if start > 0 && end < len(text) && text[end] == text[start-1] &&
+		strings.IndexByte("\"'`", text[start-1]) >= 0 {
+		return true
+	}
+	lineStart := strings.LastIndexByte(text[:start], '\n') + 1
+	before := text[lineStart:start]
+	before = before[strings.LastIndexAny(before, "{};")+1:]
+	if !strings.ContainsAny(before, ":=") {
+		return false
+	}
+	after := text[end:]
+	if i := strings.IndexAny(after, "{};\n"); i >= 0 && after[i] == '{' {
+		return false
+	}
+	return true
+}
+
+// parseHex parses the digits of a hex color.
+func parseHex(digits string) (domain.Color, bool) {
+	if len(digits) <= 4 {
+		var long strings.Builder
+		for _, c := range digits {
+			long.WriteRune(c)
+			long.WriteRune(c)
+		}
+		digits = long.String()
+	}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return domain.Color{}, false
+	}
+	return domain.Color{
+		Red:   float64(v>>24&0xff) / 255,
+		Green: float64(v>>16&0xff) / 255,
+		Blue:  float64(v>>8&0xff) / 255,
+		Alpha: float64(v&0xff) / 255,
+	}, true
+}
+
+// parseRGB parses the components of rgb() and rgba().
+func parseRGB(g [4]string) (domain.Color, bool) {
+	var channels [3]float64
+	for i := range channels {
+		v, ok := component(g[i], 255)
+		if !ok {
+			return domain.Color{}, false
+		}
+		channels[i] = v
+	}
+	alpha, ok := parseAlpha(g[3])
+	return domain.Color{
+		Red:   channels[0],
+		Green: channels[1],
+		Blue:  channels[2],
+		Alpha: alpha,
+	}, ok
+}
+
+// parseHSL parses the components of hsl() and hsla().
+func parseHSL(g [4]string) (domain.Color, bool) {
+	h, err := strconv.ParseFloat(g[0], 64)
+	if err != nil {
+		return domain.Color{}, false
+	}
+	s, ok := component(g[1]+"%", 1)
+	if !ok {
+		return domain.Color{}, false
+	}
+	l, ok := component(g[2]+"%", 1)
+	if !ok {
+		return domain.Color{}, false
+	}
+	alpha, ok := parseAlpha(g[3])
+	color := fromHSL(math.Mod(h, 360), s, l)
+	color.Alpha = alpha
+	return color, ok
+}
+
+// parseAlpha parses an optional alpha component given as a number or a
+// percentage.
+func parseAlpha(s string) (float64, bool) {
+	if s == "" {
+		return 1, true
+	}
+	return component(s, 1)
+}
+
+// component parses a number relative to scale or a percentage into the
+// range [0, 1].
+func component(s string, scale float64) (float64, bool) {
+	if strings.HasSuffix(s, "%") {
+		s, scale = strings.TrimSuffix(s, "%"), 100
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || v > scale {
+		return 0, false
+	}
+	return v / scale, true
+}
+
+// fromHSL converts a hue in degrees and a saturation and lightness in the
+// range [0, 1] into a color.
+func fromHSL(h, s, l float64) domain.Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return domain.Color{Red: r + m, Green: g + m, Blue: b + m, Alpha: 1}
+}
+
+// toHSL converts a color into a hue in degrees and a saturation and
+// lightness in the range [0, 1].
+func toHSL(color domain.Color) (h, s, l float64) {
+	r, g, b := color.Red, color.Green, color.Blue
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (hi + lo) / 2
+	d := hi - lo
+	if d == 0 {
+		return 0, 0, l
+	}
+	// The denominator is only zero for a lightness of 0 or 1 which
+	// rounding can produce for colors within an ulp of black or white.
+	if denominator := 1 - math.Abs(2*l-1); denominator > 0 {
+		s = math.Min(d/denominator, 1)
+	}
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+// byteOf converts a component in the range [0, 1] into a byte.
+func byteOf(v float64) int {
+	return int(math.Round(math.Min(math.Max(v, 0), 1) * 255))
+}
+
+// formatAlpha formats an alpha component with at most two decimals.
+func formatAlpha(a float64) string {
+	return strconv.FormatFloat(math.Round(a*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,114 @@
+package colors
+
+import (
+	"math"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// want are the detected literals in order.
+		want []string
+	}{
+		{"css declaration", "a { color: #fff; }", []string{"#fff"}},
+		{"css shorthand", "border: 1px solid #00ff00;", []string{"#00ff00"}},
+		{"css id selector", "#fade { opacity: 0; }", nil},
+		{"css selector after pseudo class", "a:hover #bad {", nil},
+		{"selector and declaration", "#bad { color: #abc; }", []string{"#abc"}},
+		{"html attribute", `<font color="#ff0000">`, []string{"#ff0000"}},
+		{"unquoted attribute", "<td bgcolor=#ccc>", []string{"#ccc"}},
+		{"json string", `{"background": "#12345678"}`, []string{"#12345678"}},
+		{"markdown heading", "#fade\n# bad", nil},
+		{"issue reference", "Fixes #123 and #4567.", nil},
+		{"html entity", "a: &#123;", nil},
+		{"invalid length", "color: #12345;", nil},
+		{"rgb", "color: rgb(255, 0, 0);", []string{"rgb(255, 0, 0)"}},
+		{"rgba with slash", "rgba(0 0 255 / 50%)", []string{"rgba(0 0 255 / 50%)"}},
+		{"hsl", "hsl(120deg, 100%, 50%)", []string{"hsl(120deg, 100%, 50%)"}},
+		{"out of range", "rgb(256, 0, 0)", nil},
+		{"ordered by position", "a: hsl(0, 0%, 0%) #000", []string{"hsl(0, 0%, 0%)", "#000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, info := range Detect(tt.text, domain.PositionEncodingUTF8) {
+				got = append(got, slice(t, tt.text, info.Range))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("detected %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("detected %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPresentations(t *testing.T) {
+	tests := []struct {
+		name  string
+		color domain.Color
+		want  [3]string
+	}{
+		{"red", domain.Color{Red: 1, Alpha: 1}, [3]string{"#ff0000", "rgb(255, 0, 0)", "hsl(0, 100%, 50%)"}},
+		{"gray", domain.Color{Red: 0.5, Green: 0.5, Blue: 0.5, Alpha: 1}, [3]string{"#808080", "rgb(128, 128, 128)", "hsl(0, 0%, 50%)"}},
+		{
+			"translucent",
+			domain.Color{Green: 1, Blue: 1, Alpha: 0.5},
+			[3]string{"#00ffff80", "rgba(0, 255, 255, 0.5)", "hsla(180, 100%, 50%, 0.5)"},
+		},
+		{
+			"within an ulp of white",
+			domain.Color{Red: 1, Green: 1, Blue: math.Nextafter(1, 0), Alpha: 1},
+			[3]string{"#ffffff", "rgb(255, 255, 255)", "hsl(60, 0%, 100%)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presentations := Presentations(tt.color, domain.Range{})
+			for i, presentation := range presentations {
+				if presentation.Label != tt.want[i] {
+					t.Errorf("presentation %d = %q, want %q", i, presentation.Label, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHSLRoundTrip(t *testing.T) {
+	for _, hex := range []string{"#000000", "#ffffff", "#ff8000", "#123456", "#abcdef", "#7f7f80"} {
+		t.Run(hex, func(t *testing.T) {
+			color, ok := parseHex(hex[1:])
+			if !ok {
+				t.Fatalf("parseHex(%q) failed", hex)
+			}
+			h, s, l := toHSL(color)
+			if s < 0 || s > 1 || l < 0 || l > 1 {
+				t.Fatalf("toHSL = %v, %v, %v out of range", h, s, l)
+			}
+			if got := Hex(fromHSL(h, s, l)); got != hex {
+				t.Errorf("round trip = %s, want %s", got, hex)
+			}
+		})
+	}
+}
+
+// slice returns the text of the range in text.
+func slice(t *testing.T, text string, rng domain.Range) string {
+	t.Helper()
+	start, err := domain.OffsetAt(text, rng.Start, domain.PositionEncodingUTF8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	end, err := domain.OffsetAt(text, rng.End, domain.PositionEncodingUTF8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return text[start:end]
+}
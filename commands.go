@@ -0,0 +1,102 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// CommandFunc runs a command with the raw arguments it was created with.
+type CommandFunc func(
+	ctx context.Context,
+	args []json.RawMessage,
+) (interface{}, error)
+
+// Commands routes workspace/executeCommand requests to the commands
+// registered by name.
+type Commands struct {
+	mu       sync.RWMutex
+	commands map[string]CommandFunc
+}
+
+// NewCommands creates a new empty command registry.
+func NewCommands() *Commands {
+	return &Commands{commands: map[string]CommandFunc{}}
+}
+
+// Handle registers the command with the given name taking raw arguments.
+func (c *Commands) Handle(name string, command CommandFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.commands[name] = command
+}
+
+// Register registers the command with the given name taking its arguments
+// as a single value of type T.
+//
+// The first argument of the command is decoded into T and validated like
+// request params; a command without arguments gets the zero T. Commands
+// with more than one argument and arguments which do not decode are
+// rejected with the CodeInvalidParams code.
+func Register[T any](
+	c *Commands,
+	name string,
+	command func(ctx context.Context, args T) (interface{}, error),
+) {
+	c.Handle(name, func(
+		ctx context.Context,
+		raw []json.RawMessage,
+	) (interface{}, error) {
+		var args T
+		switch len(raw) {
+		case 0:
+		case 1:
+			if err := domain.DecodeParams(raw[0], &args); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, &domain.Error{
+				Code: domain.CodeInvalidParams,
+				Message: fmt.Sprintf(
+					"command %s takes a single argument, got %d", name, len(raw)),
+			}
+		}
+		return command(ctx, args)
+	})
+}
+
+// Options returns the execute command server capabilities advertising the
+// names of all registered commands.
+func (c *Commands) Options() domain.ExecuteCommandOptions {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return domain.ExecuteCommandOptions{Commands: names}
+}
+
+// Execute answers an execute command request by running the command.
+//
+// Unknown commands are rejected with the CodeInvalidParams code.
+func (c *Commands) Execute(
+	ctx context.Context,
+	params domain.ExecuteCommandParams,
+) (interface{}, error) {
+	c.mu.RLock()
+	command, ok := c.commands[params.Command]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, &domain.Error{
+			Code:    domain.CodeInvalidParams,
+			Message: fmt.Sprintf("unknown command %s", params.Command),
+		}
+	}
+	return command(ctx, params.Arguments)
+}
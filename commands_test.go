@@ -0,0 +1,67 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// fixArgs are the arguments of a test command.
+type fixArgs struct {
+	URI      string                    `json:"uri"`
+	Severity domain.DiagnosticSeverity `json:"severity"`
+}
+
+func TestCommands(t *testing.T) {
+	c := NewCommands()
+	Register(c, "fix", func(_ context.Context, args fixArgs) (interface{}, error) {
+		return args, nil
+	})
+	c.Handle("count", func(_ context.Context, args []json.RawMessage) (interface{}, error) {
+		return len(args), nil
+	})
+	if got := c.Options().Commands; !equalStrings(got, []string{"count", "fix"}) {
+		t.Errorf("Options = %q, want sorted command names", got)
+	}
+
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		want    interface{}
+		wantErr bool
+	}{
+		{"raw arguments", "count", []string{`1`, `"a"`}, 2, false},
+		{"typed argument", "fix", []string{`{"uri":"file:///a.go","severity":1}`}, fixArgs{URI: "file:///a.go", Severity: 1}, false},
+		{"no arguments", "fix", nil, fixArgs{}, false},
+		{"invalid enum", "fix", []string{`{"severity":9}`}, nil, true},
+		{"undecodable argument", "fix", []string{`"a"`}, nil, true},
+		{"too many arguments", "fix", []string{`{}`, `{}`}, nil, true},
+		{"unknown command", "missing", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := domain.ExecuteCommandParams{Command: tt.command}
+			for _, arg := range tt.args {
+				params.Arguments = append(params.Arguments, json.RawMessage(arg))
+			}
+			got, err := c.Execute(context.Background(), params)
+			if tt.wantErr {
+				var rpcErr *domain.Error
+				if !errors.As(err, &rpcErr) || rpcErr.Code != domain.CodeInvalidParams {
+					t.Errorf("err = %v, want invalid params", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
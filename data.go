@@ -0,0 +1,131 @@
+package glisp
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// dataEnvelope wraps the data of an item handed out to the client with the
+// key of the provider that created it so a follow-up request for the item
+// can be routed back to the same provider.
+type dataEnvelope struct {
+	// Key identifies the provider of the item.
+	Key string `json:"glisp"`
+	// Data is the data of the item set by the provider.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// wrapData wraps the data of an item created by the provider with the
+// given key.
+func wrapData(key string, data json.RawMessage) (json.RawMessage, error) {
+	return json.Marshal(dataEnvelope{Key: key, Data: data})
+}
+
+// unwrapData returns the provider key and the original data of an item
+// wrapped by wrapData.
+//
+// ok is false if the data was not wrapped by wrapData.
+func unwrapData(data json.RawMessage) (key string, inner json.RawMessage, ok bool) {
+	if len(data) == 0 {
+		return "", nil, false
+	}
+	var envelope dataEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Key == "" {
+		return "", nil, false
+	}
+	return envelope.Key, envelope.Data, true
+}
+
+// registry holds providers of type P by key and routes resolve requests of
+// the items they handed out back to them.
+//
+// Only the items of providers which can resolve them are wrapped, so the
+// data of all other items reaches the client unchanged.
+type registry[P any] struct {
+	mu        sync.RWMutex
+	providers map[string]P
+	// resolves reports whether the provider resolves its items.
+	resolves func(provider P) bool
+}
+
+// newRegistry creates a new empty registry.
+func newRegistry[P any](resolves func(provider P) bool) *registry[P] {
+	return &registry[P]{providers: map[string]P{}, resolves: resolves}
+}
+
+// handle updates the provider with the given key, starting from the zero
+// provider if none is registered yet.
+func (r *registry[P]) handle(key string, update func(provider *P)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	provider := r.providers[key]
+	update(&provider)
+	r.providers[key] = provider
+}
+
+// lookup returns the provider with the given key.
+func (r *registry[P]) lookup(key string) (P, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[key]
+	return provider, ok
+}
+
+// sorted returns the keys and providers of the registry ordered by key.
+func (r *registry[P]) sorted() ([]string, []P) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.providers))
+	for key := range r.providers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	providers := make([]P, len(keys))
+	for i, key := range keys {
+		providers[i] = r.providers[key]
+	}
+	return keys, providers
+}
+
+// resolveProvider reports whether any provider resolves its items.
+func (r *registry[P]) resolveProvider() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, provider := range r.providers {
+		if r.resolves(provider) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrap wraps the data of an item of the provider with the given key if the
+// provider resolves its items.
+func (r *registry[P]) wrap(
+	key string,
+	provider P,
+	data json.RawMessage,
+) (json.RawMessage, error) {
+	if !r.resolves(provider) {
+		return data, nil
+	}
+	return wrapData(key, data)
+}
+
+// unwrap returns the provider of an item wrapped by wrap and the original
+// data of the item.
+//
+// ok is false if the data was not wrapped or its provider is gone or no
+// longer resolves its items.
+func (r *registry[P]) unwrap(data json.RawMessage) (provider P, inner json.RawMessage, ok bool) {
+	key, inner, ok := unwrapData(data)
+	if !ok {
+		return provider, nil, false
+	}
+	provider, ok = r.lookup(key)
+	if !ok || !r.resolves(provider) {
+		return provider, nil, false
+	}
+	return provider, inner, true
+}
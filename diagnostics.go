@@ -0,0 +1,325 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// DefaultDiagnosticsDelay is the default time the diagnostics service waits
+// for further updates to a document before publishing its diagnostics.
+const DefaultDiagnosticsDelay = 200 * time.Millisecond
+
+// maxClosedDiagnostics is the number of closed documents whose late
+// diagnostics are still ignored.
+const maxClosedDiagnostics = 1024
+
+// Diagnostics collects diagnostics per document and per source and publishes
+// them to the client.
+//
+// Updates to the same document are debounced so a burst of didChange
+// notifications results in a single publish. Diagnostics from all sources
+// of a document are merged and deduplicated before they are sent.
+//
+// Diagnostics computed for a version older than the latest known version
+// of a document are never published, and diagnostics set for a closed
+// document are ignored until it is opened again. Only the most recently
+// closed documents are remembered so the memory of closed documents stays
+// bounded.
+type Diagnostics struct {
+	// OnError is called when publishing the diagnostics of a document fails.
+	OnError func(uri string, err error)
+
+	mu       sync.Mutex
+	sendMu   sync.Mutex
+	notifier Notifier
+	delay    time.Duration
+	docs     map[string]*diagnosticsState
+	// closed maps the closed documents to the sequence number of their
+	// close, closes holds them in the order they were closed.
+	closed   map[string]uint64
+	closes   []closedDocument
+	closeSeq uint64
+}
+
+// closedDocument is a document closed by DidClose.
+type closedDocument struct {
+	uri string
+	seq uint64
+}
+
+// diagnosticsState is the diagnostics state of a single document.
+type diagnosticsState struct {
+	// version is the latest known version of the document.
+	version *int
+	// computed is the version the diagnostics were last set for.
+	computed *int
+	// sources are the diagnostics of the document keyed by their source.
+	sources map[string][]domain.Diagnostic
+	// timer is the pending publish of the document.
+	timer *time.Timer
+	// generation identifies the current timer so a timer which fired
+	// after it was replaced does not publish.
+	generation int
+	// published is the last published params of the document.
+	published []byte
+}
+
+// NewDiagnostics creates a new diagnostics service publishing through the
+// given notifier after the documents settled for delay.
+func NewDiagnostics(notifier Notifier, delay time.Duration) *Diagnostics {
+	return &Diagnostics{
+		notifier: notifier,
+		delay:    delay,
+		docs:     map[string]*diagnosticsState{},
+		closed:   map[string]uint64{},
+	}
+}
+
+// Set replaces the diagnostics of the given source for the document at uri
+// computed for the given document version and schedules a publish.
+//
+// Diagnostics computed for a version older than the latest known version of
+// the document and diagnostics of a closed document are dropped.
+func (d *Diagnostics) Set(
+	uri, source string,
+	version int,
+	diagnostics []domain.Diagnostic,
+) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, closed := d.closed[uri]; closed {
+		return
+	}
+	state := d.state(uri)
+	if state.version != nil && version < *state.version {
+		return
+	}
+	state.version = &version
+	state.computed = &version
+	if len(diagnostics) == 0 {
+		delete(state.sources, source)
+	} else {
+		state.sources[source] = diagnostics
+	}
+	d.schedule(uri, state)
+}
+
+// DidOpen records the version of the document at uri opened in the
+// client, accepting diagnostics for it again if it was closed.
+func (d *Diagnostics) DidOpen(uri string, version int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.closed, uri)
+	state := d.state(uri)
+	state.version = &version
+}
+
+// DidChange records the new version of the document at uri.
+//
+// A pending publish of the document is postponed until the document
+// settled again. Diagnostics set for an older version are not published.
+func (d *Diagnostics) DidChange(uri string, version int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, closed := d.closed[uri]; closed {
+		return
+	}
+	state := d.state(uri)
+	if state.version != nil && version < *state.version {
+		return
+	}
+	state.version = &version
+	if state.timer != nil {
+		d.schedule(uri, state)
+	}
+}
+
+// DidClose forgets the diagnostics of the document at uri and clears them
+// in the client.
+//
+// Diagnostics set for the document are ignored until DidOpen is called.
+func (d *Diagnostics) DidClose(uri string) {
+	d.mu.Lock()
+	state, ok := d.docs[uri]
+	if ok {
+		d.cancel(state)
+	}
+	delete(d.docs, uri)
+	d.close(uri)
+	d.sendMu.Lock()
+	defer d.sendMu.Unlock()
+	d.mu.Unlock()
+	d.send(uri, domain.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: []domain.Diagnostic{},
+	})
+}
+
+// Flush publishes all pending diagnostics immediately.
+func (d *Diagnostics) Flush() {
+	d.mu.Lock()
+	var pending []string
+	for uri, state := range d.docs {
+		if state.timer != nil {
+			d.cancel(state)
+			pending = append(pending, uri)
+		}
+	}
+	d.mu.Unlock()
+	sort.Strings(pending)
+	for _, uri := range pending {
+		d.publish(uri)
+	}
+}
+
+// close remembers the document at uri as closed, forgetting the document
+// closed first once maxClosedDiagnostics are remembered.
+//
+// d.mu must be held.
+func (d *Diagnostics) close(uri string) {
+	d.closeSeq++
+	d.closed[uri] = d.closeSeq
+	d.closes = append(d.closes, closedDocument{uri: uri, seq: d.closeSeq})
+	for len(d.closes) > maxClosedDiagnostics {
+		oldest := d.closes[0]
+		d.closes = d.closes[1:]
+		// The document may have been reopened or closed again since.
+		if d.closed[oldest.uri] == oldest.seq {
+			delete(d.closed, oldest.uri)
+		}
+	}
+}
+
+// state returns the state of the document at uri creating it if needed.
+//
+// d.mu must be held.
+func (d *Diagnostics) state(uri string) *diagnosticsState {
+	state, ok := d.docs[uri]
+	if !ok {
+		state = &diagnosticsState{sources: map[string][]domain.Diagnostic{}}
+		d.docs[uri] = state
+	}
+	return state
+}
+
+// schedule (re)starts the pending publish of the document at uri.
+//
+// The pending timer is replaced instead of reset since its callback may
+// already be running; the generation makes such a callback a no-op.
+//
+// d.mu must be held.
+func (d *Diagnostics) schedule(uri string, state *diagnosticsState) {
+	d.cancel(state)
+	generation := state.generation
+	state.timer = time.AfterFunc(d.delay, func() {
+		d.mu.Lock()
+		current, ok := d.docs[uri]
+		if !ok || current != state || state.generation != generation {
+			d.mu.Unlock()
+			return
+		}
+		state.timer = nil
+		d.mu.Unlock()
+		d.publish(uri)
+	})
+}
+
+// cancel stops the pending publish of the document.
+//
+// d.mu must be held.
+func (d *Diagnostics) cancel(state *diagnosticsState) {
+	if state.timer != nil {
+		state.timer.Stop()
+		state.timer = nil
+	}
+	state.generation++
+}
+
+// publish merges the diagnostics of the document at uri and sends them if
+// they differ from the last published ones.
+//
+// Nothing is sent if the diagnostics were computed for an older version
+// than the latest known version of the document. The send is ordered
+// before any later change of the document, e.g. the clear of DidClose, by
+// taking d.sendMu before releasing d.mu.
+func (d *Diagnostics) publish(uri string) {
+	d.mu.Lock()
+	state, ok := d.docs[uri]
+	if !ok || stale(state) {
+		d.mu.Unlock()
+		return
+	}
+	params := domain.PublishDiagnosticsParams{
+		URI:         uri,
+		Version:     state.computed,
+		Diagnostics: mergeDiagnostics(state.sources),
+	}
+	key, err := json.Marshal(params)
+	if err == nil && string(key) == string(state.published) {
+		d.mu.Unlock()
+		return
+	}
+	state.published = key
+	d.sendMu.Lock()
+	defer d.sendMu.Unlock()
+	d.mu.Unlock()
+	d.send(uri, params)
+}
+
+// stale reports whether the diagnostics of the document were computed for
+// an older version than its latest known version.
+func stale(state *diagnosticsState) bool {
+	return state.computed != nil &&
+		state.version != nil &&
+		*state.computed < *state.version
+}
+
+// send sends the publish diagnostics notification for uri.
+//
+// d.sendMu must be held.
+func (d *Diagnostics) send(uri string, params domain.PublishDiagnosticsParams) {
+	err := d.notifier.Notify(
+		context.Background(),
+		domain.MethodTextDocumentPublishDiagnostics,
+		params,
+	)
+	if err != nil && d.OnError != nil {
+		d.OnError(uri, err)
+	}
+}
+
+// mergeDiagnostics merges the diagnostics of all sources ordered by source
+// dropping duplicates.
+//
+// Diagnostics without a source are attributed to the source they were set
+// for.
+func mergeDiagnostics(
+	sources map[string][]domain.Diagnostic,
+) []domain.Diagnostic {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	merged := []domain.Diagnostic{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		for _, diagnostic := range sources[name] {
+			if diagnostic.Source == "" {
+				diagnostic.Source = name
+			}
+			key, err := json.Marshal(diagnostic)
+			if err == nil && seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+			merged = append(merged, diagnostic)
+		}
+	}
+	return merged
+}
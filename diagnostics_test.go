@@ -0,0 +1,226 @@
+package glisp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// recordingNotifier records the publish diagnostics params it is sent.
+type recordingNotifier struct {
+	mu        sync.Mutex
+	published []domain.PublishDiagnosticsParams
+}
+
+func (n *recordingNotifier) Notify(
+	_ context.Context,
+	_ domain.Method,
+	params interface{},
+) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.published = append(n.published, params.(domain.PublishDiagnosticsParams))
+	return nil
+}
+
+func (n *recordingNotifier) params() []domain.PublishDiagnosticsParams {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]domain.PublishDiagnosticsParams(nil), n.published...)
+}
+
+func diagnostic(message string) domain.Diagnostic {
+	return domain.Diagnostic{Message: message}
+}
+
+func TestDiagnostics(t *testing.T) {
+	const uri = "file:///a.go"
+	tests := []struct {
+		name string
+		run  func(d *Diagnostics)
+		// want are the messages of each publish, nil for a clearing one.
+		want     [][]string
+		versions []int
+	}{
+		{
+			name: "merges and deduplicates sources",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "b", 1, []domain.Diagnostic{diagnostic("x")})
+				d.Set(uri, "a", 1, []domain.Diagnostic{
+					diagnostic("y"),
+					diagnostic("y"),
+				})
+				d.Flush()
+			},
+			want:     [][]string{{"y", "x"}},
+			versions: []int{1},
+		},
+		{
+			name: "drops diagnostics for older versions",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "a", 2, []domain.Diagnostic{diagnostic("new")})
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("old")})
+				d.Flush()
+			},
+			want:     [][]string{{"new"}},
+			versions: []int{2},
+		},
+		{
+			name: "skips publishes made stale by a change",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("old")})
+				d.DidChange(uri, 2)
+				d.Flush()
+			},
+		},
+		{
+			name: "publishes after the change is diagnosed",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("old")})
+				d.DidChange(uri, 2)
+				d.Set(uri, "a", 2, []domain.Diagnostic{diagnostic("new")})
+				d.Flush()
+			},
+			want:     [][]string{{"new"}},
+			versions: []int{2},
+		},
+		{
+			name: "does not republish identical diagnostics",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("x")})
+				d.Flush()
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("x")})
+				d.Flush()
+			},
+			want:     [][]string{{"x"}},
+			versions: []int{1},
+		},
+		{
+			name: "ignores diagnostics of closed documents",
+			run: func(d *Diagnostics) {
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("x")})
+				d.DidClose(uri)
+				d.Set(uri, "a", 1, []domain.Diagnostic{diagnostic("x")})
+				d.Flush()
+			},
+			want:     [][]string{nil},
+			versions: []int{-1},
+		},
+		{
+			name: "accepts diagnostics after reopening",
+			run: func(d *Diagnostics) {
+				d.DidClose(uri)
+				d.DidOpen(uri, 3)
+				d.Set(uri, "a", 3, []domain.Diagnostic{diagnostic("x")})
+				d.Flush()
+			},
+			want:     [][]string{nil, {"x"}},
+			versions: []int{-1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			tt.run(NewDiagnostics(notifier, time.Hour))
+			got := notifier.params()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d publishes, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, params := range got {
+				if params.URI != uri {
+					t.Errorf("publish %d: uri = %q, want %q", i, params.URI, uri)
+				}
+				version := -1
+				if params.Version != nil {
+					version = *params.Version
+				}
+				if version != tt.versions[i] {
+					t.Errorf("publish %d: version = %d, want %d", i, version, tt.versions[i])
+				}
+				var messages []string
+				for _, diagnostic := range params.Diagnostics {
+					messages = append(messages, diagnostic.Message)
+				}
+				if !equalStrings(messages, tt.want[i]) {
+					t.Errorf("publish %d: messages = %q, want %q", i, messages, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnosticsDebounce(t *testing.T) {
+	const uri = "file:///a.go"
+	const delay = 100 * time.Millisecond
+	tests := []struct {
+		name string
+		// gap is the time between the changes of the burst.
+		gap time.Duration
+	}{
+		{"at once", 0},
+		{"spread across the delay", delay / 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &recordingNotifier{}
+			d := NewDiagnostics(notifier, delay)
+			// With a gap the burst lasts twice the delay, so a timer not
+			// postponed by the changes would publish in between.
+			for version := 1; version <= 8; version++ {
+				d.DidChange(uri, version)
+				d.Set(uri, "a", version, []domain.Diagnostic{diagnostic("x")})
+				time.Sleep(tt.gap)
+			}
+			deadline := time.Now().Add(time.Second)
+			for len(notifier.params()) == 0 && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			time.Sleep(2 * delay)
+			got := notifier.params()
+			if len(got) != 1 {
+				t.Fatalf("got %d publishes, want 1: %+v", len(got), got)
+			}
+			if got[0].Version == nil || *got[0].Version != 8 {
+				t.Errorf("version = %v, want 8", got[0].Version)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsClosedBound(t *testing.T) {
+	d := NewDiagnostics(&recordingNotifier{}, time.Hour)
+	d.DidClose("file:///reopened.go")
+	d.DidOpen("file:///reopened.go", 1)
+	d.DidClose("file:///first.go")
+	for i := 0; i < maxClosedDiagnostics; i++ {
+		d.DidClose(fmt.Sprintf("file:///%d.go", i))
+	}
+	if len(d.closed) != maxClosedDiagnostics {
+		t.Errorf("remembered %d closed documents, want %d",
+			len(d.closed), maxClosedDiagnostics)
+	}
+	d.Set("file:///first.go", "a", 1, []domain.Diagnostic{diagnostic("x")})
+	if _, ok := d.docs["file:///first.go"]; !ok {
+		t.Error("diagnostics of the forgotten closed document were dropped")
+	}
+	d.Set("file:///0.go", "a", 1, []domain.Diagnostic{diagnostic("x")})
+	if _, ok := d.docs["file:///0.go"]; ok {
+		t.Error("diagnostics of a remembered closed document were kept")
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
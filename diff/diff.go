@@ -0,0 +1,296 @@
+// Package diff computes the minimal text edits turning one version of a
+// document into another.
+//
+// Formatters usually produce the whole formatted document. Replacing the
+// document with a single edit moves the cursor of the user and pollutes the
+// undo history of the editor, so the lines of both versions are diffed with
+// the Myers algorithm and every changed block of lines is trimmed to the
+// characters which actually differ.
+package diff
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Edits returns the edits turning before into after ordered by position.
+//
+// The characters of the positions of the edits are counted in the
+// encoding. The empty encoding is UTF-16.
+func Edits(
+	before, after string,
+	enc domain.PositionEncodingKind,
+) []domain.TextEdit {
+	edits := []domain.TextEdit{}
+	if before == after {
+		return edits
+	}
+	a, b := splitLines(before), splitLines(after)
+	starts := lineStarts(a)
+	for _, h := range hunks(a, b) {
+		start, end := starts[h.a0], starts[h.a1]
+		old, new := before[start:end], strings.Join(b[h.b0:h.b1], "")
+		p, s := trim(old, new)
+		start, end = start+p, end-s
+		// Never split a `\r\n` line terminator.
+		if start > 0 && start < len(before) &&
+			before[start-1] == '\r' && before[start] == '\n' {
+			start, p = start-1, p-1
+		}
+		if end > start && end < len(before) &&
+			before[end-1] == '\r' && before[end] == '\n' {
+			end, s = end+1, s-1
+		}
+		edits = append(edits, domain.TextEdit{
+			Range: domain.Range{
+				Start: position(before, starts, start, enc),
+				End:   position(before, starts, end, enc),
+			},
+			NewText: new[p : len(new)-s],
+		})
+	}
+	return edits
+}
+
+// hunk replaces the lines a[a0:a1] with the lines b[b0:b1].
+type hunk struct {
+	a0, a1, b0, b1 int
+}
+
+// hunks returns the blocks of lines differing between a and b.
+func hunks(a, b []string) []hunk {
+	// Common leading and trailing lines are skipped before running the
+	// quadratic algorithm.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre &&
+		a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	deleted, inserted := myers(a[pre:len(a)-suf], b[pre:len(b)-suf])
+	var result []hunk
+	i, j := 0, 0
+	for i < len(deleted) || j < len(inserted) {
+		if i < len(deleted) && j < len(inserted) && !deleted[i] && !inserted[j] {
+			i, j = i+1, j+1
+			continue
+		}
+		h := hunk{a0: i + pre, b0: j + pre}
+		for (i < len(deleted) && deleted[i]) || (j < len(inserted) && inserted[j]) {
+			if i < len(deleted) && deleted[i] {
+				i++
+			}
+			if j < len(inserted) && inserted[j] {
+				j++
+			}
+		}
+		h.a1, h.b1 = i+pre, j+pre
+		result = append(result, h)
+	}
+	return result
+}
+
+// myers computes the shortest edit script between a and b and reports
+// which lines of a are deleted and which lines of b are inserted.
+//
+// It uses the linear space variant of the algorithm which recursively
+// splits both sequences at the middle snake of an optimal path, so memory
+// stays O(n+m) even for large edit distances.
+func myers(a, b []string) (deleted, inserted []bool) {
+	n, m := len(a), len(b)
+	size := 2*(n+m) + 3
+	d := differ{
+		a:        a,
+		b:        b,
+		deleted:  make([]bool, n),
+		inserted: make([]bool, m),
+		offset:   n + m + 1,
+		forward:  make([]int, size),
+		backward: make([]int, size),
+	}
+	d.compare(0, n, 0, m)
+	return d.deleted, d.inserted
+}
+
+// differ holds the state of myers shared by the recursive comparisons.
+type differ struct {
+	a, b              []string
+	deleted, inserted []bool
+	// offset maps the diagonal k to the index offset+k of forward and
+	// backward.
+	offset int
+	// forward and backward are the furthest reaching x of the forward
+	// and backward paths on each diagonal.
+	forward, backward []int
+}
+
+// compare marks the deleted lines of a[aLo:aHi] and the inserted lines of
+// b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		aLo, bLo = aLo+1, bLo+1
+	}
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi, bHi = aHi-1, bHi-1
+	}
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			d.inserted[y] = true
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			d.deleted[x] = true
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		d.compare(u, aHi, v, bHi)
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake
+// of an optimal path from (aLo, bLo) to (aHi, bHi).
+//
+// The furthest reaching paths are extended from both corners at once
+// until they overlap. The backward path counts x and y from the end of
+// both sequences.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	f, b, o := d.forward, d.backward, d.offset
+	f[o+1], b[o+1] = 0, 0
+	for step := 0; step <= (n+m+1)/2; step++ {
+		for k := -step; k <= step; k += 2 {
+			x := furthest(f, o, k, step)
+			y := x - k
+			x0, y0 := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x, y = x+1, y+1
+			}
+			f[o+k] = x
+			if kb := delta - k; odd && kb >= -(step-1) && kb <= step-1 &&
+				x+b[o+kb] >= n {
+				return aLo + x0, bLo + y0, aLo + x, bLo + y
+			}
+		}
+		for kb := -step; kb <= step; kb += 2 {
+			x := furthest(b, o, kb, step)
+			y := x - kb
+			x0, y0 := x, y
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x, y = x+1, y+1
+			}
+			b[o+kb] = x
+			if k := delta - kb; !odd && k >= -step && k <= step &&
+				x+f[o+k] >= n {
+				return aHi - x, bHi - y, aHi - x0, bHi - y0
+			}
+		}
+	}
+	// The paths overlap after at most half the edit distance.
+	panic("diff: no middle snake")
+}
+
+// furthest returns the x on diagonal k reached by one more edit from the
+// furthest reaching paths of the previous step stored in v.
+func furthest(v []int, offset, k, step int) int {
+	if k == -step || (k != step && v[offset+k-1] < v[offset+k+1]) {
+		return v[offset+k+1]
+	}
+	return v[offset+k-1] + 1
+}
+
+// trim returns the byte lengths of the common prefix and suffix of old
+// and new which do not overlap and end on character boundaries.
+func trim(old, new string) (prefix, suffix int) {
+	limit := min(len(old), len(new))
+	for prefix < limit && old[prefix] == new[prefix] {
+		prefix++
+	}
+	for prefix > 0 && (!runeStart(old, prefix) || !runeStart(new, prefix)) {
+		prefix--
+	}
+	limit -= prefix
+	for suffix < limit && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	for suffix > 0 &&
+		(!runeStart(old, len(old)-suffix) || !runeStart(new, len(new)-suffix)) {
+		suffix--
+	}
+	return prefix, suffix
+}
+
+// runeStart reports whether the byte offset i of s starts a character.
+func runeStart(s string, i int) bool {
+	return i >= len(s) || utf8.RuneStart(s[i])
+}
+
+// splitLines splits s into lines keeping their `\n`, `\r\n` or `\r` line
+// terminators.
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\n':
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+		default:
+			continue
+		}
+		lines = append(lines, s[start:i+1])
+		start = i + 1
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+// lineStarts returns the byte offsets of the starts of the lines followed
+// by the length of the text.
+func lineStarts(lines []string) []int {
+	starts := make([]int, len(lines)+1)
+	for i, line := range lines {
+		starts[i+1] = starts[i] + len(line)
+	}
+	return starts
+}
+
+// position returns the position of the byte offset in text.
+func position(
+	text string,
+	starts []int,
+	offset int,
+	enc domain.PositionEncodingKind,
+) domain.Position {
+	// The offset belongs to the last line starting at or before it. A
+	// text ending with a line terminator has an empty last line.
+	line := sort.Search(len(starts), func(i int) bool {
+		return starts[i] > offset
+	}) - 1
+	if line == len(starts)-1 && !terminated(text) {
+		line--
+	}
+	line = max(line, 0)
+	return domain.Position{
+		Line:      line,
+		Character: domain.EncodedLen(text[starts[line]:offset], enc),
+	}
+}
+
+// terminated reports whether text ends with a line terminator.
+func terminated(text string) bool {
+	return strings.HasSuffix(text, "\n") || strings.HasSuffix(text, "\r")
+}
@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conneroisu/glisp/apply"
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestEdits(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		// want are the edits formatted as `start-end:newText`.
+		want []string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", nil},
+		{"insert line", "a\nc\n", "a\nb\nc\n", []string{"1:0-1:0:b\n"}},
+		{"delete line", "a\nb\nc\n", "a\nc\n", []string{"1:0-2:0:"}},
+		{"change character", "foo(a)\n", "foo(b)\n", []string{"0:4-0:5:b"}},
+		{"append to empty", "", "a\n", []string{"0:0-0:0:a\n"}},
+		{"delete all", "a\n", "", []string{"0:0-1:0:"}},
+		{"missing final newline", "a\nb", "a\nc", []string{"1:0-1:1:c"}},
+		{"keeps crlf together", "a\r\nb\r\n", "a\nb\r\n", []string{"0:1-1:0:\n"}},
+		{"utf-16 columns", "😀a\n", "😀b\n", []string{"0:2-0:3:b"}},
+		{
+			"two hunks",
+			"a\nb\nc\nd\ne\n",
+			"a\nB\nc\nd\nE\n",
+			[]string{"1:0-1:1:B", "4:0-4:1:E"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := Edits(tt.before, tt.after, domain.PositionEncodingUTF16)
+			var got []string
+			for _, edit := range edits {
+				got = append(got, formatEdit(edit))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("edits = %q, want %q", got, tt.want)
+			}
+			assertRoundTrip(t, tt.before, tt.after, domain.PositionEncodingUTF16)
+		})
+	}
+}
+
+func TestEditsRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pieces := []string{"a", "b", "é", "😀", "\n", "\r\n", "\r", " "}
+	random := func() string {
+		var b strings.Builder
+		for i := rng.Intn(40); i > 0; i-- {
+			b.WriteString(pieces[rng.Intn(len(pieces))])
+		}
+		return b.String()
+	}
+	encodings := []domain.PositionEncodingKind{
+		domain.PositionEncodingUTF8,
+		domain.PositionEncodingUTF16,
+		domain.PositionEncodingUTF32,
+	}
+	for i := 0; i < 2000; i++ {
+		before, after := random(), random()
+		assertRoundTrip(t, before, after, encodings[i%len(encodings)])
+	}
+}
+
+func TestMyersMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	lines := func() []string {
+		out := make([]string, rng.Intn(30))
+		for i := range out {
+			out[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return out
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := lines(), lines()
+		deleted, inserted := myers(a, b)
+		var kept []string
+		for x, line := range a {
+			if !deleted[x] {
+				kept = append(kept, line)
+			}
+		}
+		var common []string
+		for y, line := range b {
+			if !inserted[y] {
+				common = append(common, line)
+			}
+		}
+		if strings.Join(kept, "") != strings.Join(common, "") {
+			t.Fatalf("myers(%q, %q) keeps %q of a but %q of b", a, b, kept, common)
+		}
+		if len(kept) != lcs(a, b) {
+			t.Fatalf("myers(%q, %q) keeps %d lines, want %d", a, b, len(kept), lcs(a, b))
+		}
+	}
+}
+
+func TestMyersLargeDistance(t *testing.T) {
+	// Entirely different documents must not need memory quadratic in the
+	// number of lines.
+	a, b := make([]string, 5000), make([]string, 5000)
+	for i := range a {
+		a[i], b[i] = "a\n", "b\n"
+	}
+	deleted, inserted := myers(a, b)
+	for i := range a {
+		if !deleted[i] || !inserted[i] {
+			t.Fatalf("line %d is kept", i)
+		}
+	}
+}
+
+func assertRoundTrip(t *testing.T, before, after string, enc domain.PositionEncodingKind) {
+	t.Helper()
+	edits := Edits(before, after, enc)
+	got, err := apply.Edits(before, edits, enc)
+	if err != nil {
+		t.Fatalf("applying edits of %q -> %q: %v", before, after, err)
+	}
+	if got != after {
+		t.Fatalf("applying edits of %q -> %q gives %q: %v", before, after, got, edits)
+	}
+}
+
+func formatEdit(edit domain.TextEdit) string {
+	r := edit.Range
+	return strings.Join([]string{
+		strconv.Itoa(r.Start.Line) + ":" + strconv.Itoa(r.Start.Character),
+		strconv.Itoa(r.End.Line) + ":" + strconv.Itoa(r.End.Character) + ":" + edit.NewText,
+	}, "-")
+}
+
+// lcs returns the length of the longest common subsequence of a and b.
+func lcs(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(cur[j], prev[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,169 @@
+package glisp
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+	"github.com/conneroisu/glisp/structure"
+)
+
+// Document is an open text document.
+type Document struct {
+	// URI is the uri of the document.
+	URI string
+	// LanguageID is the language id of the document.
+	LanguageID string
+	// Version is the version of the document, increasing after each
+	// change.
+	Version int
+	// Text is the content of the document.
+	Text string
+}
+
+// DocumentStore keeps the text of the documents opened by the client.
+//
+// Servers feed it the didOpen, didChange and didClose notifications and
+// answer requests from the stored text.
+type DocumentStore struct {
+	mu   sync.RWMutex
+	docs map[string]Document
+}
+
+// NewDocumentStore creates a new empty document store.
+func NewDocumentStore() *DocumentStore {
+	return &DocumentStore{docs: map[string]Document{}}
+}
+
+// Open stores a document opened by the client.
+func (s *DocumentStore) Open(item domain.TextDocumentItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[item.URI] = Document{
+		URI:        item.URI,
+		LanguageID: item.LanguageID,
+		Version:    item.Version,
+		Text:       item.Text,
+	}
+}
+
+// Update replaces the text of an open document with the full text of a
+// change.
+//
+// It reports whether the document is open.
+func (s *DocumentStore) Update(uri string, version int, text string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		return false
+	}
+	doc.Version, doc.Text = version, text
+	s.docs[uri] = doc
+	return true
+}
+
+// Close removes a document closed by the client.
+func (s *DocumentStore) Close(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.docs, uri)
+}
+
+// Version returns the version of the open document at uri or nil if it is
+// not open.
+func (s *DocumentStore) Version(uri string) *int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	doc, ok := s.docs[uri]
+	if !ok {
+		return nil
+	}
+	return &doc.Version
+}
+
+// Get returns the open document at uri.
+func (s *DocumentStore) Get(uri string) (Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	doc, ok := s.docs[uri]
+	return doc, ok
+}
+
+// URIs returns the uris of all open documents in sorted order.
+func (s *DocumentStore) URIs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	uris := make([]string, 0, len(s.docs))
+	for uri := range s.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// FoldingRanges answers a folding range request with the folding ranges
+// computed from the structure of the document.
+//
+// The ranges are limited to what the client supports.
+func (s *DocumentStore) FoldingRanges(
+	caps domain.ClientCapabilities,
+	params domain.FoldingRangeParams,
+	syntax structure.Syntax,
+) ([]domain.FoldingRange, error) {
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	ranges := structure.FoldingRanges(doc.Text, syntax)
+	folding := caps.TextDocument.FoldingRange
+	if folding.LineFoldingOnly {
+		for i := range ranges {
+			ranges[i].StartCharacter, ranges[i].EndCharacter = nil, nil
+		}
+	}
+	if !folding.FoldingRange.CollapsedText {
+		for i := range ranges {
+			ranges[i].CollapsedText = ""
+		}
+	}
+	if folding.RangeLimit > 0 && len(ranges) > folding.RangeLimit {
+		ranges = ranges[:folding.RangeLimit]
+	}
+	return ranges, nil
+}
+
+// SelectionRanges answers a selection range request with the selection
+// ranges computed from the bracket nesting of the document.
+func (s *DocumentStore) SelectionRanges(
+	params domain.SelectionRangeParams,
+	syntax structure.Syntax,
+	enc domain.PositionEncodingKind,
+) ([]domain.SelectionRange, error) {
+	doc, err := s.document(params.TextDocument.URI)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := structure.SelectionRanges(doc.Text, params.Positions, syntax, enc)
+	if err != nil {
+		return nil, &domain.Error{
+			Code:    domain.CodeInvalidParams,
+			Message: err.Error(),
+		}
+	}
+	return ranges, nil
+}
+
+// document returns the open document at uri or an error for the client
+// if it is not open.
+func (s *DocumentStore) document(uri string) (Document, error) {
+	doc, ok := s.Get(uri)
+	if !ok {
+		return Document{}, &domain.Error{
+			Code:    domain.CodeInvalidParams,
+			Message: fmt.Sprintf("document %s is not open", uri),
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,106 @@
+package glisp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+	"github.com/conneroisu/glisp/structure"
+)
+
+func TestDocumentStore(t *testing.T) {
+	const uri = "file:///a.go"
+	s := NewDocumentStore()
+	if s.Update(uri, 2, "x") {
+		t.Error("updated a document which is not open")
+	}
+	s.Open(domain.TextDocumentItem{URI: uri, LanguageID: "go", Version: 1, Text: "a"})
+	if !s.Update(uri, 2, "b") {
+		t.Error("did not update an open document")
+	}
+	doc, ok := s.Get(uri)
+	if !ok || doc.Version != 2 || doc.Text != "b" || doc.LanguageID != "go" {
+		t.Errorf("Get = %+v, %v", doc, ok)
+	}
+	if v := s.Version(uri); v == nil || *v != 2 {
+		t.Errorf("Version = %v, want 2", v)
+	}
+	if uris := s.URIs(); !equalStrings(uris, []string{uri}) {
+		t.Errorf("URIs = %q", uris)
+	}
+	s.Close(uri)
+	if v := s.Version(uri); v != nil {
+		t.Errorf("Version after close = %v, want nil", *v)
+	}
+}
+
+func TestDocumentStoreFoldingRanges(t *testing.T) {
+	const uri = "file:///a.go"
+	s := NewDocumentStore()
+	s.Open(domain.TextDocumentItem{
+		URI:  uri,
+		Text: "// a\n// b\nfunc f() {\n\tif x {\n\t\ty()\n\t}\n}",
+	})
+	tests := []struct {
+		name       string
+		rangeLimit int
+		want       int
+	}{
+		{"no limit", 0, 3},
+		{"limited", 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var caps domain.ClientCapabilities
+			caps.TextDocument.FoldingRange.RangeLimit = tt.rangeLimit
+			params := domain.FoldingRangeParams{}
+			params.TextDocument.URI = uri
+			ranges, err := s.FoldingRanges(caps, params, structure.DefaultSyntax)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ranges) != tt.want {
+				t.Errorf("got %d ranges, want %d", len(ranges), tt.want)
+			}
+		})
+	}
+
+	params := domain.FoldingRangeParams{}
+	params.TextDocument.URI = "file:///closed.go"
+	var rpcErr *domain.Error
+	_, err := s.FoldingRanges(domain.ClientCapabilities{}, params, structure.DefaultSyntax)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != domain.CodeInvalidParams {
+		t.Errorf("err = %v, want invalid params", err)
+	}
+}
+
+func TestDocumentStoreSelectionRanges(t *testing.T) {
+	const uri = "file:///a.go"
+	s := NewDocumentStore()
+	s.Open(domain.TextDocumentItem{URI: uri, Text: "f(x)"})
+	tests := []struct {
+		name    string
+		pos     domain.Position
+		wantErr bool
+	}{
+		{"inside", domain.Position{Character: 2}, false},
+		{"past the last line", domain.Position{Line: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := domain.SelectionRangeParams{Positions: []domain.Position{tt.pos}}
+			params.TextDocument.URI = uri
+			ranges, err := s.SelectionRanges(params, structure.DefaultSyntax, domain.PositionEncodingUTF16)
+			var rpcErr *domain.Error
+			if tt.wantErr {
+				if !errors.As(err, &rpcErr) || rpcErr.Code != domain.CodeInvalidParams {
+					t.Errorf("err = %v, want invalid params", err)
+				}
+				return
+			}
+			if err != nil || len(ranges) != 1 {
+				t.Errorf("SelectionRanges = %+v, %v", ranges, err)
+			}
+		})
+	}
+}
@@ -15,4 +15,132 @@ type ClientCapabilities struct {
 	CodeActionProvider bool `json:"codeActionProvider"`
 	// CompletionProvider is a map of completion providers.
 	CompletionProvider map[string]any `json:"completionProvider"`
+	// General are the general client capabilities.
+	General GeneralClientCapabilities `json:"general"`
+	// Workspace are the workspace specific client capabilities.
+	Workspace WorkspaceClientCapabilities `json:"workspace"`
+	// Window are the window specific client capabilities.
+	Window WindowClientCapabilities `json:"window"`
+	// TextDocument are the text document specific client capabilities.
+	TextDocument TextDocumentClientCapabilities `json:"textDocument"`
+}
+
+// GeneralClientCapabilities are the general client capabilities.
+type GeneralClientCapabilities struct {
+	// PositionEncodings are the position encodings supported by the client
+	// in the order of preference. UTF-16 is always supported even if it
+	// is missing.
+	PositionEncodings []PositionEncodingKind `json:"positionEncodings,omitempty"`
+}
+
+// WorkspaceClientCapabilities are the workspace specific client
+// capabilities.
+type WorkspaceClientCapabilities struct {
+	// WorkspaceEdit are the capabilities of the client for workspace
+	// edits.
+	WorkspaceEdit WorkspaceEditClientCapabilities `json:"workspaceEdit"`
+	// Diagnostics are the client capabilities specific to diagnostics
+	// in the workspace.
+	Diagnostics DiagnosticWorkspaceClientCapabilities `json:"diagnostics"`
+	// Symbol are the client capabilities specific to the workspace/symbol
+	// request.
+	Symbol WorkspaceSymbolClientCapabilities `json:"symbol"`
+	// CodeLens are the client capabilities specific to code lenses in
+	// the workspace.
+	CodeLens CodeLensWorkspaceClientCapabilities `json:"codeLens"`
+	// SemanticTokens are the client capabilities specific to semantic
+	// tokens in the workspace.
+	SemanticTokens SemanticTokensWorkspaceClientCapabilities `json:"semanticTokens"`
+	// InlayHint are the client capabilities specific to inlay hints in
+	// the workspace.
+	InlayHint InlayHintWorkspaceClientCapabilities `json:"inlayHint"`
+	// ExecuteCommand are the client capabilities specific to the
+	// workspace/executeCommand request.
+	ExecuteCommand ExecuteCommandClientCapabilities `json:"executeCommand"`
+}
+
+// TextDocumentClientCapabilities are the text document specific client
+// capabilities.
+type TextDocumentClientCapabilities struct {
+	// PublishDiagnostics are the capabilities specific to the
+	// textDocument/publishDiagnostics notification.
+	PublishDiagnostics PublishDiagnosticsClientCapabilities `json:"publishDiagnostics"`
+	// Diagnostic are the capabilities specific to pull diagnostics.
+	Diagnostic DiagnosticClientCapabilities `json:"diagnostic"`
+	// Hover are the capabilities specific to the textDocument/hover
+	// request.
+	Hover HoverClientCapabilities `json:"hover"`
+	// Completion are the capabilities specific to the
+	// textDocument/completion request.
+	Completion CompletionClientCapabilities `json:"completion"`
+	// SignatureHelp are the capabilities specific to the
+	// textDocument/signatureHelp request.
+	SignatureHelp SignatureHelpClientCapabilities `json:"signatureHelp"`
+	// Declaration are the capabilities specific to the
+	// textDocument/declaration request.
+	Declaration DefinitionClientCapabilities `json:"declaration"`
+	// Definition are the capabilities specific to the
+	// textDocument/definition request.
+	Definition DefinitionClientCapabilities `json:"definition"`
+	// TypeDefinition are the capabilities specific to the
+	// textDocument/typeDefinition request.
+	TypeDefinition DefinitionClientCapabilities `json:"typeDefinition"`
+	// Implementation are the capabilities specific to the
+	// textDocument/implementation request.
+	Implementation DefinitionClientCapabilities `json:"implementation"`
+	// References are the capabilities specific to the
+	// textDocument/references request.
+	References ReferenceClientCapabilities `json:"references"`
+	// DocumentHighlight are the capabilities specific to the
+	// textDocument/documentHighlight request.
+	DocumentHighlight DocumentHighlightClientCapabilities `json:"documentHighlight"`
+	// DocumentSymbol are the capabilities specific to the
+	// textDocument/documentSymbol request.
+	DocumentSymbol DocumentSymbolClientCapabilities `json:"documentSymbol"`
+	// Formatting are the capabilities specific to the
+	// textDocument/formatting request.
+	Formatting FormattingClientCapabilities `json:"formatting"`
+	// RangeFormatting are the capabilities specific to the
+	// textDocument/rangeFormatting request.
+	RangeFormatting FormattingClientCapabilities `json:"rangeFormatting"`
+	// OnTypeFormatting are the capabilities specific to the
+	// textDocument/onTypeFormatting request.
+	OnTypeFormatting FormattingClientCapabilities `json:"onTypeFormatting"`
+	// Rename are the capabilities specific to the textDocument/rename
+	// request.
+	Rename RenameClientCapabilities `json:"rename"`
+	// CodeLens are the capabilities specific to the
+	// textDocument/codeLens request.
+	CodeLens CodeLensClientCapabilities `json:"codeLens"`
+	// DocumentLink are the capabilities specific to the
+	// textDocument/documentLink request.
+	DocumentLink DocumentLinkClientCapabilities `json:"documentLink"`
+	// SemanticTokens are the capabilities specific to the semantic
+	// tokens requests.
+	SemanticTokens SemanticTokensClientCapabilities `json:"semanticTokens"`
+	// InlayHint are the capabilities specific to the
+	// textDocument/inlayHint request.
+	InlayHint InlayHintClientCapabilities `json:"inlayHint"`
+	// FoldingRange are the capabilities specific to the
+	// textDocument/foldingRange request.
+	FoldingRange FoldingRangeClientCapabilities `json:"foldingRange"`
+	// SelectionRange are the capabilities specific to the
+	// textDocument/selectionRange request.
+	SelectionRange SelectionRangeClientCapabilities `json:"selectionRange"`
+	// CallHierarchy are the capabilities specific to the call
+	// hierarchy requests.
+	CallHierarchy HierarchyClientCapabilities `json:"callHierarchy"`
+	// TypeHierarchy are the capabilities specific to the type
+	// hierarchy requests.
+	TypeHierarchy HierarchyClientCapabilities `json:"typeHierarchy"`
+	// ColorProvider are the capabilities specific to the
+	// textDocument/documentColor and textDocument/colorPresentation
+	// requests.
+	ColorProvider ColorClientCapabilities `json:"colorProvider"`
+	// LinkedEditingRange are the capabilities specific to the
+	// textDocument/linkedEditingRange request.
+	LinkedEditingRange LinkedEditingRangeClientCapabilities `json:"linkedEditingRange"`
+	// CodeAction are the capabilities specific to the
+	// textDocument/codeAction request.
+	CodeAction CodeActionClientCapabilities `json:"codeAction"`
 }
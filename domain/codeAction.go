@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MethodCodeActionResolve is the code action resolve request method used
+// to compute additional properties, like the edit, of a code action.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeAction_resolve
+const MethodCodeActionResolve Method = "codeAction/resolve"
+
+// CodeActionRequest is a request for a code action to the language server.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_code
+type CodeActionRequest struct {
+	// CodeActionRequest embeds the Request struct
+	Request
+	// Params are the parameters for the code action request.
+	Params TextDocumentCodeActionParams `json:"params"`
+}
+
+// TextDocumentCodeActionParams are the parameters for a code action request.
+type TextDocumentCodeActionParams struct {
+	// TextDocument identifies the text document for the code action
+	// request.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Range is the range for the code action request.
+	Range Range `json:"range"`
+	// Context is the context for the code action request.
+	Context CodeActionContext `json:"context"`
+}
+
+// TextDocumentCodeActionResponse is the response for a code action request.
+type TextDocumentCodeActionResponse struct {
+	// TextDocumentCodeActionResponse embeds the Response struct
+	Response
+	// Result is the result for the code action request.
+	Result []CodeAction `json:"result"`
+}
+
+// Method returns the method for the code action response
+func (r TextDocumentCodeActionResponse) Method() string {
+	return string(MethodRequestTextDocumentCodeAction)
+}
+
+// CodeActionContext is the context for a code action request.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeActionContext
+type CodeActionContext struct {
+	// Diagnostics are the diagnostics overlapping the requested range.
+	Diagnostics []Diagnostic `json:"diagnostics"`
+	// Only are the requested kinds of actions. Actions not of these kinds
+	// are filtered out by the client.
+	Only []CodeActionKind `json:"only,omitempty"`
+	// TriggerKind is the reason why code actions were requested.
+	TriggerKind CodeActionTriggerKind `json:"triggerKind,omitempty"`
+}
+
+// CodeActionKind is the kind of a code action.
+//
+// Kinds are a hierarchical list of identifiers separated by `.`, e.g.
+// `refactor.extract.function`.
+type CodeActionKind string
+
+const (
+	// CodeActionKindEmpty is the empty kind.
+	CodeActionKindEmpty CodeActionKind = ""
+	// CodeActionKindQuickFix is the base kind for quickfix actions.
+	CodeActionKindQuickFix CodeActionKind = "quickfix"
+	// CodeActionKindRefactor is the base kind for refactoring actions.
+	CodeActionKindRefactor CodeActionKind = "refactor"
+	// CodeActionKindRefactorExtract is the base kind for refactoring
+	// extraction actions.
+	CodeActionKindRefactorExtract CodeActionKind = "refactor.extract"
+	// CodeActionKindRefactorInline is the base kind for refactoring inline
+	// actions.
+	CodeActionKindRefactorInline CodeActionKind = "refactor.inline"
+	// CodeActionKindRefactorRewrite is the base kind for refactoring
+	// rewrite actions.
+	CodeActionKindRefactorRewrite CodeActionKind = "refactor.rewrite"
+	// CodeActionKindSource is the base kind for source actions applying to
+	// the entire file.
+	CodeActionKindSource CodeActionKind = "source"
+	// CodeActionKindSourceOrganizeImports is the base kind for an organize
+	// imports source action.
+	CodeActionKindSourceOrganizeImports CodeActionKind = "source.organizeImports"
+	// CodeActionKindSourceFixAll is the base kind for an auto-fix source
+	// action.
+	CodeActionKindSourceFixAll CodeActionKind = "source.fixAll"
+)
+
+// Contains reports whether other is k or a sub kind of k.
+//
+// The empty kind contains every kind.
+func (k CodeActionKind) Contains(other CodeActionKind) bool {
+	return k == CodeActionKindEmpty || k == other ||
+		strings.HasPrefix(string(other), string(k)+".")
+}
+
+// CodeAction is a code action for a given text document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeAction
+type CodeAction struct {
+	// Title is the title for the code action.
+	Title string `json:"title"`
+	// Kind is the kind of the code action.
+	Kind CodeActionKind `json:"kind,omitempty"`
+	// Diagnostics are the diagnostics this code action resolves.
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	// IsPreferred marks this as a preferred action, e.g. the quick fix
+	// applied by the auto fix command.
+	IsPreferred bool `json:"isPreferred,omitempty"`
+	// Disabled marks the code action as unavailable in the current context.
+	Disabled *CodeActionDisabled `json:"disabled,omitempty"`
+	// Edit is the edit for the code action.
+	Edit *WorkspaceEdit `json:"edit,omitempty"`
+	// Command is the command for the code action.
+	Command *Command `json:"command,omitempty"`
+	// Data is preserved between a code action request and a code action
+	// resolve request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// CodeActionDisabled explains why a code action is disabled.
+type CodeActionDisabled struct {
+	// Reason is a human readable description of why the code action is
+	// currently disabled.
+	Reason string `json:"reason"`
+}
+
+// CodeActionResolveRequest is a request to resolve additional properties
+// of a code action.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeAction_resolve
+type CodeActionResolveRequest struct {
+	// CodeActionResolveRequest embeds the Request struct
+	Request
+	// Params is the code action to resolve.
+	Params CodeAction `json:"params"`
+}
+
+// CodeActionResolveResponse is the response for a code action resolve
+// request.
+type CodeActionResolveResponse struct {
+	// CodeActionResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved code action.
+	Result CodeAction `json:"result"`
+}
+
+// Method returns the method for the code action resolve response
+func (r CodeActionResolveResponse) Method() string {
+	return string(MethodCodeActionResolve)
+}
+
+// CodeActionOptions are the server capabilities for code actions.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeActionOptions
+type CodeActionOptions struct {
+	// CodeActionKinds are the kinds of code actions the server may return.
+	CodeActionKinds []CodeActionKind `json:"codeActionKinds,omitempty"`
+	// ResolveProvider is whether the server provides support to resolve
+	// additional information for a code action.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// BoolOrCodeActionOptions is the code action provider of the server which
+// is either a boolean or the code action options of the server.
+type BoolOrCodeActionOptions struct {
+	// Enabled is whether the server provides code actions. It is implied
+	// if Options is set.
+	Enabled bool
+	// Options are the code action options of the server sent instead of
+	// Enabled if set.
+	Options *CodeActionOptions
+}
+
+// MarshalJSON marshals the provider as the options if set, otherwise as a
+// boolean.
+func (p BoolOrCodeActionOptions) MarshalJSON() ([]byte, error) {
+	if p.Options != nil {
+		return json.Marshal(p.Options)
+	}
+	return json.Marshal(p.Enabled)
+}
+
+// UnmarshalJSON unmarshals the provider from a boolean or code action
+// options.
+func (p *BoolOrCodeActionOptions) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		*p = BoolOrCodeActionOptions{Enabled: enabled}
+		return nil
+	}
+	var options CodeActionOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return fmt.Errorf("code action provider must be a boolean or options: %w", err)
+	}
+	*p = BoolOrCodeActionOptions{Enabled: true, Options: &options}
+	return nil
+}
+
+// CodeActionClientCapabilities are the client capabilities for code
+// actions.
+type CodeActionClientCapabilities struct {
+	// DynamicRegistration is whether code action supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// CodeActionLiteralSupport is set when the client supports code action
+	// literals as a valid response of the code action request.
+	CodeActionLiteralSupport *CodeActionLiteralSupport `json:"codeActionLiteralSupport,omitempty"`
+	// IsPreferredSupport is whether code action supports the isPreferred
+	// property.
+	IsPreferredSupport bool `json:"isPreferredSupport,omitempty"`
+	// DisabledSupport is whether code action supports the disabled
+	// property.
+	DisabledSupport bool `json:"disabledSupport,omitempty"`
+	// DataSupport is whether code action supports the data property which
+	// is preserved between a code action and a resolve request.
+	DataSupport bool `json:"dataSupport,omitempty"`
+	// ResolveSupport are the properties the client can resolve lazily.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+	// HonorsChangeAnnotations is whether the client honors the change
+	// annotations in text edits and resource operations.
+	HonorsChangeAnnotations bool `json:"honorsChangeAnnotations,omitempty"`
+}
+
+// CodeActionLiteralSupport lists the code action kinds supported by a
+// client.
+type CodeActionLiteralSupport struct {
+	// CodeActionKind are the code action kinds supported by the client.
+	CodeActionKind struct {
+		// ValueSet are the code action kinds supported by the client.
+		ValueSet []CodeActionKind `json:"valueSet"`
+	} `json:"codeActionKind"`
+}
+
+// ResolveSupport lists the properties a client can resolve lazily.
+type ResolveSupport struct {
+	// Properties are the properties that a client can resolve lazily.
+	Properties []string `json:"properties"`
+}
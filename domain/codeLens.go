@@ -0,0 +1,115 @@
+package domain
+
+import "encoding/json"
+
+// Code Lens Methods
+const (
+	// MethodCodeLensResolve is the code lens resolve request method used
+	// to compute the command of a code lens lazily.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeLens_resolve
+	MethodCodeLensResolve Method = "codeLens/resolve"
+
+	// MethodWorkspaceCodeLensRefresh is the request sent from the server to
+	// the client asking it to refresh all code lenses.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeLens_refresh
+	MethodWorkspaceCodeLensRefresh Method = "workspace/codeLens/refresh"
+)
+
+// CodeLensRequest is a request to list the code lenses of a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_codeLens
+type CodeLensRequest struct {
+	// CodeLensRequest embeds the Request struct
+	Request
+	// Params are the parameters for the code lens request.
+	Params CodeLensParams `json:"params"`
+}
+
+// CodeLensParams are the parameters of a code lens request.
+type CodeLensParams struct {
+	// TextDocument is the document to request code lenses for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// CodeLensResponse is the response for a code lens request.
+type CodeLensResponse struct {
+	// CodeLensResponse embeds the Response struct
+	Response
+	// Result are the code lenses of the document.
+	Result []CodeLens `json:"result"`
+}
+
+// Method returns the method for the code lens response
+func (r CodeLensResponse) Method() string {
+	return string(MethodTextDocumentCodeLens)
+}
+
+// CodeLens is a command shown inline with the source text, like the number
+// of references.
+//
+// A code lens is unresolved when no command is associated with it. The
+// command is computed by a code lens resolve request for performance
+// reasons.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#codeLens
+type CodeLens struct {
+	// Range is the range in which the code lens is valid. It should only
+	// span a single line.
+	Range Range `json:"range"`
+	// Command is the command the code lens represents.
+	Command *Command `json:"command,omitempty"`
+	// Data is preserved between a code lens request and a code lens
+	// resolve request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// CodeLensResolveRequest is a request to resolve the command of a code
+// lens.
+type CodeLensResolveRequest struct {
+	// CodeLensResolveRequest embeds the Request struct
+	Request
+	// Params is the code lens to resolve.
+	Params CodeLens `json:"params"`
+}
+
+// CodeLensResolveResponse is the response for a code lens resolve
+// request.
+type CodeLensResolveResponse struct {
+	// CodeLensResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved code lens.
+	Result CodeLens `json:"result"`
+}
+
+// Method returns the method for the code lens resolve response
+func (r CodeLensResolveResponse) Method() string {
+	return string(MethodCodeLensResolve)
+}
+
+// CodeLensOptions are the server capabilities for code lenses.
+type CodeLensOptions struct {
+	// ResolveProvider is whether the server provides support to resolve
+	// code lenses.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// CodeLensClientCapabilities are the client capabilities for code lenses.
+type CodeLensClientCapabilities struct {
+	// DynamicRegistration is whether code lens supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// CodeLensWorkspaceClientCapabilities are the client capabilities for code
+// lenses in the workspace.
+type CodeLensWorkspaceClientCapabilities struct {
+	// RefreshSupport is whether the client supports the
+	// workspace/codeLens/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
@@ -0,0 +1,182 @@
+package domain
+
+// Color and Linked Editing Methods
+const (
+	// MethodTextDocumentDocumentColor is the request method listing the
+	// color references of a document.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_documentColor
+	MethodTextDocumentDocumentColor Method = "textDocument/documentColor"
+
+	// MethodTextDocumentColorPresentation is the request method listing the
+	// ways to write a color.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_colorPresentation
+	MethodTextDocumentColorPresentation Method = "textDocument/colorPresentation"
+
+	// MethodTextDocumentLinkedEditingRange is the request method returning
+	// the ranges which are edited together with the range at a position.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_linkedEditingRange
+	MethodTextDocumentLinkedEditingRange Method = "textDocument/linkedEditingRange"
+)
+
+// DocumentColorRequest is a request to list the color references of a
+// document.
+type DocumentColorRequest struct {
+	// DocumentColorRequest embeds the Request struct
+	Request
+	// Params are the parameters for the document color request.
+	Params DocumentColorParams `json:"params"`
+}
+
+// DocumentColorParams are the parameters of a document color request.
+type DocumentColorParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentColorResponse is the response for a document color request.
+type DocumentColorResponse struct {
+	// DocumentColorResponse embeds the Response struct
+	Response
+	// Result are the color references of the document.
+	Result []ColorInformation `json:"result"`
+}
+
+// Method returns the method for the document color response
+func (r DocumentColorResponse) Method() string {
+	return string(MethodTextDocumentDocumentColor)
+}
+
+// ColorInformation is a color reference in a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#colorInformation
+type ColorInformation struct {
+	// Range is the range in the document where the color appears.
+	Range Range `json:"range"`
+	// Color is the actual color value of the range.
+	Color Color `json:"color"`
+}
+
+// Color is a color in RGBA space with components in the range [0, 1].
+type Color struct {
+	// Red is the red component of the color.
+	Red float64 `json:"red"`
+	// Green is the green component of the color.
+	Green float64 `json:"green"`
+	// Blue is the blue component of the color.
+	Blue float64 `json:"blue"`
+	// Alpha is the alpha component of the color.
+	Alpha float64 `json:"alpha"`
+}
+
+// ColorPresentationRequest is a request to list the ways to write a color.
+type ColorPresentationRequest struct {
+	// ColorPresentationRequest embeds the Request struct
+	Request
+	// Params are the parameters for the color presentation request.
+	Params ColorPresentationParams `json:"params"`
+}
+
+// ColorPresentationParams are the parameters of a color presentation
+// request.
+type ColorPresentationParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Color is the color to request presentations for.
+	Color Color `json:"color"`
+	// Range is the range where the color would be inserted.
+	Range Range `json:"range"`
+}
+
+// ColorPresentationResponse is the response for a color presentation
+// request.
+type ColorPresentationResponse struct {
+	// ColorPresentationResponse embeds the Response struct
+	Response
+	// Result are the presentations of the color.
+	Result []ColorPresentation `json:"result"`
+}
+
+// Method returns the method for the color presentation response
+func (r ColorPresentationResponse) Method() string {
+	return string(MethodTextDocumentColorPresentation)
+}
+
+// ColorPresentation is a way to write a color.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#colorPresentation
+type ColorPresentation struct {
+	// Label is the label of this color presentation. It is shown on the
+	// color picker header and inserted if no text edit is given.
+	Label string `json:"label"`
+	// TextEdit is the edit applied when selecting this presentation.
+	TextEdit *TextEdit `json:"textEdit,omitempty"`
+	// AdditionalTextEdits are additional edits applied when selecting
+	// this presentation.
+	AdditionalTextEdits []TextEdit `json:"additionalTextEdits,omitempty"`
+}
+
+// LinkedEditingRangeRequest is a request for the ranges edited together
+// with the range at a position, like the tags of an HTML element.
+type LinkedEditingRangeRequest struct {
+	// LinkedEditingRangeRequest embeds the Request struct
+	Request
+	// Params are the parameters for the linked editing range request.
+	Params LinkedEditingRangeParams `json:"params"`
+}
+
+// LinkedEditingRangeParams are the parameters of a linked editing range
+// request.
+type LinkedEditingRangeParams struct {
+	// TextDocumentPositionParams is the position to find linked ranges
+	// for.
+	TextDocumentPositionParams
+}
+
+// LinkedEditingRangeResponse is the response for a linked editing range
+// request.
+type LinkedEditingRangeResponse struct {
+	// LinkedEditingRangeResponse embeds the Response struct
+	Response
+	// Result are the linked ranges or nil if there are none.
+	Result *LinkedEditingRanges `json:"result"`
+}
+
+// Method returns the method for the linked editing range response
+func (r LinkedEditingRangeResponse) Method() string {
+	return string(MethodTextDocumentLinkedEditingRange)
+}
+
+// LinkedEditingRanges are ranges which have the same content and are
+// edited together.
+type LinkedEditingRanges struct {
+	// Ranges are the linked ranges. They must have identical length and
+	// content and must not overlap.
+	Ranges []Range `json:"ranges"`
+	// WordPattern is an optional regular expression describing valid
+	// contents of the ranges.
+	WordPattern string `json:"wordPattern,omitempty"`
+}
+
+// ColorClientCapabilities are the client capabilities for document
+// colors.
+type ColorClientCapabilities struct {
+	// DynamicRegistration is whether document color supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// LinkedEditingRangeClientCapabilities are the client capabilities for
+// linked editing ranges.
+type LinkedEditingRangeClientCapabilities struct {
+	// DynamicRegistration is whether linked editing ranges support
+	// dynamic registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
@@ -0,0 +1,511 @@
+package domain
+
+import "encoding/json"
+
+// MethodCompletionItemResolve is the completion item resolve request method
+// used to compute additional information, like the documentation, of a
+// completion item lazily.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#completionItem_resolve
+const MethodCompletionItemResolve Method = "completionItem/resolve"
+
+// CompletionRequest is a request for a completion to the language server
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_completion
+type CompletionRequest struct {
+	// CompletionRequest embeds the Request struct
+	Request
+	// Params are the parameters for the completion request
+	Params CompletionParams `json:"params"`
+}
+
+// CompletionParams is a struct for the completion params
+type CompletionParams struct {
+	// CompletionParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+	// Context is the completion context. It is only available if the client
+	// specifies to send it.
+	Context *CompletionContext `json:"context,omitempty"`
+}
+
+// CompletionContext contains additional information about the context in
+// which a completion request is triggered.
+type CompletionContext struct {
+	// TriggerKind is how the completion was triggered.
+	TriggerKind CompletionTriggerKind `json:"triggerKind"`
+	// TriggerCharacter is the trigger character that has triggered code
+	// complete if the trigger kind is TriggerCharacter.
+	TriggerCharacter string `json:"triggerCharacter,omitempty"`
+}
+
+// CompletionResponse is a response for a completion to the language server
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_completion
+type CompletionResponse struct {
+	// CompletionResponse embeds the Response struct
+	Response
+	// Result is the result of the completion request
+	Result CompletionList `json:"result"`
+}
+
+// Method returns the method for the completion response
+func (r CompletionResponse) Method() string {
+	return string(MethodRequestTextDocumentCompletion)
+}
+
+// CompletionList is a list of completion items to be presented in the
+// editor.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#completionList
+type CompletionList struct {
+	// IsIncomplete signals that the list is not complete and further
+	// typing should result in recomputing the list.
+	IsIncomplete bool `json:"isIncomplete"`
+	// ItemDefaults are default values of properties of the items which are
+	// used when an item does not set them.
+	ItemDefaults *CompletionItemDefaults `json:"itemDefaults,omitempty"`
+	// Items are the completion items.
+	Items []CompletionItem `json:"items"`
+}
+
+// CompletionItemDefaults are the default values of the items of a
+// completion list.
+//
+// Only one of EditRange and InsertReplaceRange is sent.
+type CompletionItemDefaults struct {
+	// CommitCharacters is the default commit character set.
+	CommitCharacters []string `json:"commitCharacters,omitempty"`
+	// EditRange is the default edit range.
+	EditRange *Range `json:"-"`
+	// InsertReplaceRange is the default edit range with distinct insert
+	// and replace ranges.
+	InsertReplaceRange *InsertReplaceRange `json:"-"`
+	// InsertTextFormat is the default insert text format.
+	InsertTextFormat InsertTextFormat `json:"insertTextFormat,omitempty"`
+	// InsertTextMode is the default insert text mode.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+	// Data is the default data value.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MarshalJSON marshals the defaults with the edit range set.
+func (d CompletionItemDefaults) MarshalJSON() ([]byte, error) {
+	type defaults CompletionItemDefaults
+	var editRange interface{}
+	if d.InsertReplaceRange != nil {
+		editRange = d.InsertReplaceRange
+	} else if d.EditRange != nil {
+		editRange = d.EditRange
+	}
+	return json.Marshal(struct {
+		defaults
+		EditRange interface{} `json:"editRange,omitempty"`
+	}{defaults(d), editRange})
+}
+
+// UnmarshalJSON unmarshals the defaults setting the field of the kind of
+// edit range sent.
+func (d *CompletionItemDefaults) UnmarshalJSON(data []byte) error {
+	type defaults CompletionItemDefaults
+	var raw struct {
+		defaults
+		EditRange json.RawMessage `json:"editRange"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*d = CompletionItemDefaults(raw.defaults)
+	if !isInsertReplace(raw.EditRange) {
+		return unmarshalOptional(raw.EditRange, &d.EditRange)
+	}
+	return unmarshalOptional(raw.EditRange, &d.InsertReplaceRange)
+}
+
+// InsertReplaceRange is an edit range with distinct ranges used when the
+// completion is inserted or replaces the word at the cursor.
+type InsertReplaceRange struct {
+	// Insert is the range if the insert is requested.
+	Insert Range `json:"insert"`
+	// Replace is the range if the replace is requested.
+	Replace Range `json:"replace"`
+}
+
+// InsertReplaceEdit is a special text edit to provide an insert and a
+// replace operation.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#insertReplaceEdit
+type InsertReplaceEdit struct {
+	// NewText is the string to be inserted.
+	NewText string `json:"newText"`
+	// Insert is the range if the insert is requested.
+	Insert Range `json:"insert"`
+	// Replace is the range if the replace is requested.
+	Replace Range `json:"replace"`
+}
+
+// CompletionItem is a struct for a completion item
+//
+// Only one of TextEdit and InsertReplaceEdit is sent.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#completionItem
+type CompletionItem struct {
+	// Label is the label for the completion item
+	Label string `json:"label"`
+	// LabelDetails are additional details for the label
+	LabelDetails *CompletionItemLabelDetails `json:"labelDetails,omitempty"`
+	// Kind is the kind of the completion item
+	Kind CompletionItemKind `json:"kind,omitempty"`
+	// Tags are the tags for the completion item
+	Tags []CompletionItemTag `json:"tags,omitempty"`
+	// Detail is the detail for the completion item
+	Detail string `json:"detail,omitempty"`
+	// Documentation is the documentation for the completion item, either a
+	// string or markup content
+	Documentation *StringOrMarkupContent `json:"documentation,omitempty"`
+	// Preselect selects this item when showing the completion list
+	Preselect bool `json:"preselect,omitempty"`
+	// SortText is used when comparing this item with other items, the label
+	// is used when it is empty
+	SortText string `json:"sortText,omitempty"`
+	// FilterText is used when filtering a set of completion items, the
+	// label is used when it is empty
+	FilterText string `json:"filterText,omitempty"`
+	// InsertText is inserted when selecting this completion, the label is
+	// used when it is empty
+	InsertText string `json:"insertText,omitempty"`
+	// InsertTextFormat is the format of the insert text
+	InsertTextFormat InsertTextFormat `json:"insertTextFormat,omitempty"`
+	// InsertTextMode is how whitespace and indentation is handled during
+	// the insertion of the completion item
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+	// TextEdit is the edit applied when selecting this completion
+	TextEdit *TextEdit `json:"-"`
+	// InsertReplaceEdit is the edit applied when selecting this completion
+	// if the client supports insert and replace edits
+	InsertReplaceEdit *InsertReplaceEdit `json:"-"`
+	// TextEditText is the edit text used if the completion item is part of
+	// a completion list with a default edit range
+	TextEditText string `json:"textEditText,omitempty"`
+	// AdditionalTextEdits are edits applied when selecting this completion
+	// which must not overlap with the main edit, e.g. adding an import
+	AdditionalTextEdits []TextEdit `json:"additionalTextEdits,omitempty"`
+	// CommitCharacters are the characters that accept the completion when
+	// typed while the completion is active
+	CommitCharacters []string `json:"commitCharacters,omitempty"`
+	// Command is executed after inserting this completion
+	Command *Command `json:"command,omitempty"`
+	// Data is preserved between a completion request and a completion
+	// item resolve request
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MarshalJSON marshals the completion item with its text edit set.
+func (c CompletionItem) MarshalJSON() ([]byte, error) {
+	type item CompletionItem
+	var edit interface{}
+	if c.InsertReplaceEdit != nil {
+		edit = c.InsertReplaceEdit
+	} else if c.TextEdit != nil {
+		edit = c.TextEdit
+	}
+	return json.Marshal(struct {
+		item
+		TextEdit interface{} `json:"textEdit,omitempty"`
+	}{item(c), edit})
+}
+
+// UnmarshalJSON unmarshals the completion item setting the field of the
+// kind of text edit sent.
+func (c *CompletionItem) UnmarshalJSON(data []byte) error {
+	type item CompletionItem
+	var raw struct {
+		item
+		TextEdit json.RawMessage `json:"textEdit"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = CompletionItem(raw.item)
+	if !isInsertReplace(raw.TextEdit) {
+		return unmarshalOptional(raw.TextEdit, &c.TextEdit)
+	}
+	return unmarshalOptional(raw.TextEdit, &c.InsertReplaceEdit)
+}
+
+// isInsertReplace reports whether the raw edit or range has distinct
+// insert and replace ranges.
+func isInsertReplace(data json.RawMessage) bool {
+	var probe struct {
+		Insert *Range `json:"insert"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.Insert != nil
+}
+
+// unmarshalOptional unmarshals data into v unless data is empty.
+func unmarshalOptional(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// CompletionItemLabelDetails are additional details for a completion item
+// label.
+type CompletionItemLabelDetails struct {
+	// Detail is rendered less prominently directly after the label,
+	// without any spacing, e.g. function signatures.
+	Detail string `json:"detail,omitempty"`
+	// Description is rendered less prominently after the detail, e.g.
+	// fully qualified names or file paths.
+	Description string `json:"description,omitempty"`
+}
+
+// CompletionItemTag is an enum for extra annotations of completion items.
+type CompletionItemTag int
+
+const (
+	// CompletionItemTagDeprecated renders a completion as obsolete, usually
+	// using a strike-out.
+	CompletionItemTagDeprecated CompletionItemTag = iota + 1
+)
+
+var completionItemTagNames = []string{
+	"Deprecated",
+}
+
+// String returns the string representation of the CompletionItemTag.
+func (t CompletionItemTag) String() string {
+	return enumString("CompletionItemTag", 1, completionItemTagNames, int(t))
+}
+
+// IsValid reports whether t is a known CompletionItemTag.
+func (t CompletionItemTag) IsValid() bool {
+	return enumValid(1, completionItemTagNames, int(t))
+}
+
+// ParseCompletionItemTag returns the CompletionItemTag with the given name.
+func ParseCompletionItemTag(name string) (CompletionItemTag, error) {
+	v, err := enumParse("CompletionItemTag", 1, completionItemTagNames, name)
+	return CompletionItemTag(v), err
+}
+
+// CompletionItemResolveRequest is a request to resolve additional
+// information of a completion item.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#completionItem_resolve
+type CompletionItemResolveRequest struct {
+	// CompletionItemResolveRequest embeds the Request struct
+	Request
+	// Params is the completion item to resolve.
+	Params CompletionItem `json:"params"`
+}
+
+// CompletionItemResolveResponse is the response for a completion item
+// resolve request.
+type CompletionItemResolveResponse struct {
+	// CompletionItemResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved completion item.
+	Result CompletionItem `json:"result"`
+}
+
+// Method returns the method for the completion item resolve response
+func (r CompletionItemResolveResponse) Method() string {
+	return string(MethodCompletionItemResolve)
+}
+
+// CompletionOptions are the server capabilities for completion.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#completionOptions
+type CompletionOptions struct {
+	// TriggerCharacters are the characters that trigger completion
+	// automatically.
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+	// AllCommitCharacters are the characters that commit every completion
+	// item.
+	AllCommitCharacters []string `json:"allCommitCharacters,omitempty"`
+	// ResolveProvider is whether the server provides support to resolve
+	// additional information for a completion item.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+	// CompletionItem are the server capabilities specific to completion
+	// items.
+	CompletionItem *CompletionItemOptions `json:"completionItem,omitempty"`
+}
+
+// CompletionItemOptions are the server capabilities for completion items.
+type CompletionItemOptions struct {
+	// LabelDetailsSupport is whether the server supports label details
+	// when receiving a completion item in a resolve call.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
+}
+
+// CompletionClientCapabilities are the client capabilities for completion.
+type CompletionClientCapabilities struct {
+	// DynamicRegistration is whether completion supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// CompletionItem are the client capabilities specific to completion
+	// items.
+	CompletionItem CompletionItemClientCapabilities `json:"completionItem"`
+	// CompletionItemKind are the completion item kinds supported by the
+	// client.
+	CompletionItemKind struct {
+		// ValueSet are the completion item kinds supported by the client.
+		ValueSet []CompletionItemKind `json:"valueSet,omitempty"`
+	} `json:"completionItemKind"`
+	// InsertTextMode is the default insert text mode of the client.
+	InsertTextMode InsertTextMode `json:"insertTextMode,omitempty"`
+	// ContextSupport is whether the client sends additional context
+	// information for a completion request.
+	ContextSupport bool `json:"contextSupport,omitempty"`
+	// CompletionList are the client capabilities specific to completion
+	// lists.
+	CompletionList struct {
+		// ItemDefaults are the item defaults supported by the client.
+		ItemDefaults []string `json:"itemDefaults,omitempty"`
+	} `json:"completionList"`
+}
+
+// CompletionItemClientCapabilities are the client capabilities for
+// completion items.
+type CompletionItemClientCapabilities struct {
+	// SnippetSupport is whether the client supports snippets as insert
+	// text.
+	SnippetSupport bool `json:"snippetSupport,omitempty"`
+	// CommitCharactersSupport is whether the client supports commit
+	// characters on a completion item.
+	CommitCharactersSupport bool `json:"commitCharactersSupport,omitempty"`
+	// DeprecatedSupport is whether the client supports the deprecated
+	// property on a completion item.
+	DeprecatedSupport bool `json:"deprecatedSupport,omitempty"`
+	// PreselectSupport is whether the client supports the preselect
+	// property on a completion item.
+	PreselectSupport bool `json:"preselectSupport,omitempty"`
+	// TagSupport are the tags supported by the client.
+	TagSupport *struct {
+		// ValueSet are the tags supported by the client.
+		ValueSet []CompletionItemTag `json:"valueSet"`
+	} `json:"tagSupport,omitempty"`
+	// InsertReplaceSupport is whether the client supports insert replace
+	// edits to control different behavior if a completion item is inserted
+	// in the text or should replace text.
+	InsertReplaceSupport bool `json:"insertReplaceSupport,omitempty"`
+	// ResolveSupport are the properties the client can resolve lazily.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+	// LabelDetailsSupport is whether the client supports label details.
+	LabelDetailsSupport bool `json:"labelDetailsSupport,omitempty"`
+}
+
+// CompletionItemKind is an enum for completion item kinds.
+type CompletionItemKind int
+
+const (
+	// Text is a completion item kind
+	//
+	// Deprecated: use CompletionItemKindText.
+	Text CompletionItemKind = iota + 1
+	// CompletionItemKindMethod is a completion item kind for a method or function completion
+	CompletionItemKindMethod
+	// CompletionItemKindFunction is a completion item kind for a function completion
+	CompletionItemKindFunction
+	// CompletionItemKindConstructor is a completion item kind for a constructor completion
+	CompletionItemKindConstructor
+	// CompletionItemKindField is a completion item kind for a field completion
+	CompletionItemKindField
+	// CompletionItemKindVariable is a completion item kind for a variable completion
+	CompletionItemKindVariable
+	// CompletionItemKindClass is a completion item kind for a class completion
+	CompletionItemKindClass
+	// CompletionItemKindInterface is a completion item kind for an interface completion
+	CompletionItemKindInterface
+	// CompletionItemKindModule is a completion item kind for a module completion
+	CompletionItemKindModule
+	// CompletionItemKindProperty is a completion item kind for a property completion
+	CompletionItemKindProperty
+	// CompletionItemKindUnit is a completion item kind for a unit
+	CompletionItemKindUnit
+	// CompletionItemKindValue is a completion item kind for a value
+	CompletionItemKindValue
+	// CompletionItemKindEnum is a completion item kind for an enum
+	CompletionItemKindEnum
+	// CompletionItemKindKeyword is a completion item kind for a keyword
+	CompletionItemKindKeyword
+	// CompletionItemKindSnippet is a completion item kind for a snippet
+	CompletionItemKindSnippet
+	// CompletionItemKindColor is a completion item kind for a color
+	CompletionItemKindColor
+	// CompletionItemKindFile is a completion item kind for a file
+	CompletionItemKindFile
+	// CompletionItemKindReference is a completion item kind for a reference
+	CompletionItemKindReference
+	// CompletionItemKindFolder is a completion item kind for a folder
+	CompletionItemKindFolder
+	// CompletionItemKindEnumMember is a completion item kind for an enum member
+	CompletionItemKindEnumMember
+	// CompletionItemKindConstant is a completion item kind for a constant
+	CompletionItemKindConstant
+	// CompletionItemKindStruct is a completion item kind for a struct
+	CompletionItemKindStruct
+	// CompletionItemKindEvent is a completion item kind for an event
+	CompletionItemKindEvent
+	// CompletionItemKindOperator is a completion item kind for an operator
+	CompletionItemKindOperator
+	// CompletionItemKindTypeParameter is a completion item kind for a type parameter
+	CompletionItemKindTypeParameter
+)
+
+// CompletionItemKindText is a completion item kind for a text completion
+const CompletionItemKindText = Text
+
+var completionItemKindNames = []string{
+	"Text",
+	"Method",
+	"Function",
+	"Constructor",
+	"Field",
+	"Variable",
+	"Class",
+	"Interface",
+	"Module",
+	"Property",
+	"Unit",
+	"Value",
+	"Enum",
+	"Keyword",
+	"Snippet",
+	"Color",
+	"File",
+	"Reference",
+	"Folder",
+	"EnumMember",
+	"Constant",
+	"Struct",
+	"Event",
+	"Operator",
+	"TypeParameter",
+}
+
+// String returns the string representation of the CompletionItemKind.
+func (c CompletionItemKind) String() string {
+	return enumString("CompletionItemKind", 1, completionItemKindNames, int(c))
+}
+
+// IsValid reports whether c is a known CompletionItemKind.
+func (c CompletionItemKind) IsValid() bool {
+	return enumValid(1, completionItemKindNames, int(c))
+}
+
+// ParseCompletionItemKind returns the CompletionItemKind with the given
+// name.
+func ParseCompletionItemKind(name string) (CompletionItemKind, error) {
+	v, err := enumParse("CompletionItemKind", 1, completionItemKindNames, name)
+	return CompletionItemKind(v), err
+}
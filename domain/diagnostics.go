@@ -1,5 +1,18 @@
 package domain
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MethodTextDocumentPublishDiagnostics is the publish diagnostics
+// notification method sent from the server to the client.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_publishDiagnostics
+const MethodTextDocumentPublishDiagnostics Method = "textDocument/publishDiagnostics"
+
 // DiagnosticSeverity is an enum for diagnostic severities.
 type DiagnosticSeverity int
 
@@ -14,14 +27,28 @@ const (
 	DiagnosticHint
 )
 
+var diagnosticSeverityNames = []string{
+	"Error",
+	"Warning",
+	"Information",
+	"Hint",
+}
+
 // String returns the string representation of the DiagnosticSeverity.
 func (d DiagnosticSeverity) String() string {
-	return [...]string{
-		"Error",
-		"Warning",
-		"Information",
-		"Hint",
-	}[d-1]
+	return enumString("DiagnosticSeverity", 1, diagnosticSeverityNames, int(d))
+}
+
+// IsValid reports whether d is a known DiagnosticSeverity.
+func (d DiagnosticSeverity) IsValid() bool {
+	return enumValid(1, diagnosticSeverityNames, int(d))
+}
+
+// ParseDiagnosticSeverity returns the DiagnosticSeverity with the given
+// name.
+func ParseDiagnosticSeverity(name string) (DiagnosticSeverity, error) {
+	v, err := enumParse("DiagnosticSeverity", 1, diagnosticSeverityNames, name)
+	return DiagnosticSeverity(v), err
 }
 
 // PublishDiagnosticsNotification is the notification for publishing diagnostics.
@@ -37,25 +64,435 @@ type PublishDiagnosticsNotification struct {
 
 // Method returns the method for the publish diagnostics notification
 func (r PublishDiagnosticsNotification) Method() string {
-	return "textDocument/publishDiagnostics"
+	return string(MethodTextDocumentPublishDiagnostics)
 }
 
 // PublishDiagnosticsParams are the parameters for the publish diagnostics notification.
 type PublishDiagnosticsParams struct {
 	// URI is the uri for the diagnostics.
 	URI string `json:"uri"`
+	// Version is the version number of the document the diagnostics are
+	// published for.
+	Version *int `json:"version,omitempty"`
 	// Diagnostics are the diagnostics for the uri.
 	Diagnostics []Diagnostic `json:"diagnostics"`
 }
 
 // Diagnostic is a struct for a diagnostic.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#diagnostic
 type Diagnostic struct {
 	// Range is the range for the diagnostic.
 	Range Range `json:"range"`
 	// Severity is the severity for the diagnostic.
-	Severity DiagnosticSeverity `json:"severity"`
+	Severity DiagnosticSeverity `json:"severity,omitempty"`
+	// Code is the code for the diagnostic.
+	Code *DiagnosticCode `json:"code,omitempty"`
+	// CodeDescription describes the code of the diagnostic.
+	CodeDescription *CodeDescription `json:"codeDescription,omitempty"`
 	// Source is the source for the diagnostic.
-	Source string `json:"source"`
+	Source string `json:"source,omitempty"`
 	// Message is the message for the diagnostic.
 	Message string `json:"message"`
+	// Tags are additional metadata about the diagnostic.
+	Tags []DiagnosticTag `json:"tags,omitempty"`
+	// RelatedInformation are related locations of the diagnostic, e.g. when
+	// duplicating a symbol in a scope.
+	RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+	// Data is preserved between a publish diagnostics notification and a
+	// subsequent code action request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// DiagnosticCode is the code of a diagnostic which is either an integer or
+// a string.
+//
+// The zero value is the empty string code.
+type DiagnosticCode struct {
+	value   string
+	integer bool
+}
+
+// NewDiagnosticCode creates a string diagnostic code.
+func NewDiagnosticCode(code string) DiagnosticCode {
+	return DiagnosticCode{value: code}
+}
+
+// NewIntegerDiagnosticCode creates an integer diagnostic code.
+func NewIntegerDiagnosticCode(code int) DiagnosticCode {
+	return DiagnosticCode{value: strconv.Itoa(code), integer: true}
+}
+
+// String returns the string representation of the DiagnosticCode.
+func (c DiagnosticCode) String() string {
+	return c.value
+}
+
+// Int returns the integer value of the code and whether the code is an
+// integer.
+func (c DiagnosticCode) Int() (int, bool) {
+	if !c.integer {
+		return 0, false
+	}
+	code, err := strconv.Atoi(c.value)
+	return code, err == nil
+}
+
+// MarshalJSON marshals the code as a JSON number or string.
+func (c DiagnosticCode) MarshalJSON() ([]byte, error) {
+	if c.integer {
+		return []byte(c.value), nil
+	}
+	return json.Marshal(c.value)
+}
+
+// UnmarshalJSON unmarshals the code from a JSON number or string.
+func (c *DiagnosticCode) UnmarshalJSON(data []byte) error {
+	var code int
+	if err := json.Unmarshal(data, &code); err == nil {
+		*c = NewIntegerDiagnosticCode(code)
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("diagnostic code must be an integer or string: %w", err)
+	}
+	*c = NewDiagnosticCode(value)
+	return nil
+}
+
+// CodeDescription describes the code of a diagnostic.
+type CodeDescription struct {
+	// Href is an uri to open with more information about the diagnostic
+	// error.
+	Href string `json:"href"`
+}
+
+// DiagnosticTag is an enum for diagnostic tags.
+type DiagnosticTag int
+
+const (
+	// DiagnosticTagUnnecessary marks unused or unnecessary code. Clients
+	// are allowed to render it faded out instead of having an error
+	// squiggle.
+	DiagnosticTagUnnecessary DiagnosticTag = iota + 1
+	// DiagnosticTagDeprecated marks deprecated or obsolete code. Clients
+	// are allowed to render it with a strike through.
+	DiagnosticTagDeprecated
+)
+
+var diagnosticTagNames = []string{
+	"Unnecessary",
+	"Deprecated",
+}
+
+// String returns the string representation of the DiagnosticTag.
+func (d DiagnosticTag) String() string {
+	return enumString("DiagnosticTag", 1, diagnosticTagNames, int(d))
+}
+
+// IsValid reports whether d is a known DiagnosticTag.
+func (d DiagnosticTag) IsValid() bool {
+	return enumValid(1, diagnosticTagNames, int(d))
+}
+
+// ParseDiagnosticTag returns the DiagnosticTag with the given name.
+func ParseDiagnosticTag(name string) (DiagnosticTag, error) {
+	v, err := enumParse("DiagnosticTag", 1, diagnosticTagNames, name)
+	return DiagnosticTag(v), err
+}
+
+// DiagnosticRelatedInformation is a related message and source code
+// location for a diagnostic.
+type DiagnosticRelatedInformation struct {
+	// Location is the location of this related diagnostic information.
+	Location Location `json:"location"`
+	// Message is the message of this related diagnostic information.
+	Message string `json:"message"`
+}
+
+// Pull Diagnostic Methods
+const (
+	// MethodTextDocumentDiagnostic is the document diagnostic request
+	// method used by the client to pull the diagnostics of a document.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_pullDiagnostics
+	MethodTextDocumentDiagnostic Method = "textDocument/diagnostic"
+
+	// MethodWorkspaceDiagnostic is the workspace diagnostic request method
+	// used by the client to pull the diagnostics of the whole workspace.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_workspaceDiagnostic
+	MethodWorkspaceDiagnostic Method = "workspace/diagnostic"
+
+	// MethodWorkspaceDiagnosticRefresh is the request sent from the server
+	// to the client asking it to refresh all pulled diagnostics.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#diagnostic_refresh
+	MethodWorkspaceDiagnosticRefresh Method = "workspace/diagnostic/refresh"
+)
+
+// PublishDiagnosticsClientCapabilities are the client capabilities for the
+// publish diagnostics notification.
+type PublishDiagnosticsClientCapabilities struct {
+	// RelatedInformation is whether the client accepts related information.
+	RelatedInformation bool `json:"relatedInformation,omitempty"`
+	// VersionSupport is whether the client interprets the version property
+	// of the publish diagnostics params.
+	VersionSupport bool `json:"versionSupport,omitempty"`
+	// TagSupport are the diagnostic tags supported by the client.
+	TagSupport *DiagnosticTagSupport `json:"tagSupport,omitempty"`
+	// CodeDescriptionSupport is whether the client supports a code
+	// description property.
+	CodeDescriptionSupport bool `json:"codeDescriptionSupport,omitempty"`
+	// DataSupport is whether the client preserves the data property of a
+	// diagnostic between a publish diagnostics notification and a code
+	// action request.
+	DataSupport bool `json:"dataSupport,omitempty"`
+}
+
+// DiagnosticTagSupport lists the diagnostic tags supported by a client.
+type DiagnosticTagSupport struct {
+	// ValueSet are the tags supported by the client.
+	ValueSet []DiagnosticTag `json:"valueSet"`
+}
+
+// DiagnosticClientCapabilities are the client capabilities for pull
+// diagnostics.
+type DiagnosticClientCapabilities struct {
+	// DynamicRegistration is whether the client supports dynamic
+	// registration of pull diagnostics.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// RelatedDocumentSupport is whether the client supports related
+	// documents for document diagnostic pulls.
+	RelatedDocumentSupport bool `json:"relatedDocumentSupport,omitempty"`
+}
+
+// DiagnosticWorkspaceClientCapabilities are the workspace client
+// capabilities for diagnostics.
+type DiagnosticWorkspaceClientCapabilities struct {
+	// RefreshSupport is whether the client supports the
+	// workspace/diagnostic/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
+
+// DiagnosticOptions are the server capabilities for pull diagnostics.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#diagnosticOptions
+type DiagnosticOptions struct {
+	// Identifier is an optional identifier under which the diagnostics are
+	// managed by the client.
+	Identifier string `json:"identifier,omitempty"`
+	// InterFileDependencies is whether the language has inter file
+	// dependencies so a change in one file can result in diagnostics in
+	// another.
+	InterFileDependencies bool `json:"interFileDependencies"`
+	// WorkspaceDiagnostics is whether the server provides support for
+	// workspace diagnostics as well.
+	WorkspaceDiagnostics bool `json:"workspaceDiagnostics"`
+}
+
+// DocumentDiagnosticRequest is a request from the client to pull the
+// diagnostics of a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_pullDiagnostics
+type DocumentDiagnosticRequest struct {
+	// DocumentDiagnosticRequest embeds the Request struct
+	Request
+	// Params are the parameters for the document diagnostic request.
+	Params DocumentDiagnosticParams `json:"params"`
+}
+
+// DocumentDiagnosticParams are the parameters of a document diagnostic
+// request.
+type DocumentDiagnosticParams struct {
+	// TextDocument is the text document to pull the diagnostics for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Identifier is the additional identifier provided during registration.
+	Identifier string `json:"identifier,omitempty"`
+	// PreviousResultID is the result id of a previous response if provided.
+	PreviousResultID string `json:"previousResultId,omitempty"`
+}
+
+// DocumentDiagnosticResponse is the response for a document diagnostic
+// request.
+type DocumentDiagnosticResponse struct {
+	// DocumentDiagnosticResponse embeds the Response struct
+	Response
+	// Result is the diagnostic report of the document.
+	Result DocumentDiagnosticReport `json:"result"`
+}
+
+// Method returns the method for the document diagnostic response
+func (r DocumentDiagnosticResponse) Method() string {
+	return string(MethodTextDocumentDiagnostic)
+}
+
+// DocumentDiagnosticReportKind is the kind of a document diagnostic report.
+type DocumentDiagnosticReportKind string
+
+const (
+	// DocumentDiagnosticReportKindFull is a report containing the full set
+	// of diagnostics of a document.
+	DocumentDiagnosticReportKindFull DocumentDiagnosticReportKind = "full"
+	// DocumentDiagnosticReportKindUnchanged is a report indicating that the
+	// diagnostics of the previous report are still valid.
+	DocumentDiagnosticReportKindUnchanged DocumentDiagnosticReportKind = "unchanged"
+)
+
+// DocumentDiagnosticReport is a full or unchanged diagnostic report of a
+// document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#documentDiagnosticReport
+type DocumentDiagnosticReport struct {
+	// Kind is the kind of the report.
+	Kind DocumentDiagnosticReportKind `json:"kind"`
+	// ResultID is an optional result id. It is required for unchanged
+	// reports.
+	ResultID string `json:"resultId,omitempty"`
+	// Items are the diagnostics of a full report.
+	Items []Diagnostic `json:"items,omitempty"`
+	// RelatedDocuments are the diagnostics of related documents keyed by
+	// their uri.
+	RelatedDocuments map[string]DocumentDiagnosticReport `json:"relatedDocuments,omitempty"`
+}
+
+// MarshalJSON marshals the report always including the items of a full
+// report.
+func (r DocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	type report DocumentDiagnosticReport
+	if r.Kind != DocumentDiagnosticReportKindFull {
+		r.Items = nil
+		return json.Marshal(report(r))
+	}
+	items := r.Items
+	if items == nil {
+		items = []Diagnostic{}
+	}
+	return json.Marshal(struct {
+		report
+		Items []Diagnostic `json:"items"`
+	}{report(r), items})
+}
+
+// NewFullDocumentDiagnosticReport creates a full report with the given
+// result id and diagnostics.
+func NewFullDocumentDiagnosticReport(
+	resultID string,
+	items []Diagnostic,
+) DocumentDiagnosticReport {
+	return DocumentDiagnosticReport{
+		Kind:     DocumentDiagnosticReportKindFull,
+		ResultID: resultID,
+		Items:    items,
+	}
+}
+
+// NewUnchangedDocumentDiagnosticReport creates an unchanged report for the
+// given result id.
+func NewUnchangedDocumentDiagnosticReport(
+	resultID string,
+) DocumentDiagnosticReport {
+	return DocumentDiagnosticReport{
+		Kind:     DocumentDiagnosticReportKindUnchanged,
+		ResultID: resultID,
+	}
+}
+
+// DiagnosticServerCancellationData is the error data returned when the
+// server cancels a diagnostic request.
+type DiagnosticServerCancellationData struct {
+	// RetriggerRequest is whether the client should retrigger the request.
+	RetriggerRequest bool `json:"retriggerRequest"`
+}
+
+// WorkspaceDiagnosticRequest is a request from the client to pull the
+// diagnostics of the workspace.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_workspaceDiagnostic
+type WorkspaceDiagnosticRequest struct {
+	// WorkspaceDiagnosticRequest embeds the Request struct
+	Request
+	// Params are the parameters for the workspace diagnostic request.
+	Params WorkspaceDiagnosticParams `json:"params"`
+}
+
+// WorkspaceDiagnosticParams are the parameters of a workspace diagnostic
+// request.
+type WorkspaceDiagnosticParams struct {
+	// Identifier is the additional identifier provided during registration.
+	Identifier string `json:"identifier,omitempty"`
+	// PreviousResultIDs are the currently known diagnostic reports with
+	// their previous result ids.
+	PreviousResultIDs []PreviousResultID `json:"previousResultIds"`
+}
+
+// PreviousResultID is a previous result id of a document in a workspace
+// pull request.
+type PreviousResultID struct {
+	// URI is the uri for which the client knows a result id.
+	URI string `json:"uri"`
+	// Value is the value of the previous result id.
+	Value string `json:"value"`
+}
+
+// WorkspaceDiagnosticResponse is the response for a workspace diagnostic
+// request.
+type WorkspaceDiagnosticResponse struct {
+	// WorkspaceDiagnosticResponse embeds the Response struct
+	Response
+	// Result is the diagnostic report of the workspace.
+	Result WorkspaceDiagnosticReport `json:"result"`
+}
+
+// Method returns the method for the workspace diagnostic response
+func (r WorkspaceDiagnosticResponse) Method() string {
+	return string(MethodWorkspaceDiagnostic)
+}
+
+// WorkspaceDiagnosticReport is a workspace diagnostic report.
+type WorkspaceDiagnosticReport struct {
+	// Items are the reports of the documents in the workspace.
+	Items []WorkspaceDocumentDiagnosticReport `json:"items"`
+}
+
+// WorkspaceDocumentDiagnosticReport is the diagnostic report of a single
+// document in a workspace diagnostic report.
+type WorkspaceDocumentDiagnosticReport struct {
+	// WorkspaceDocumentDiagnosticReport embeds the DocumentDiagnosticReport
+	// struct
+	DocumentDiagnosticReport
+	// URI is the uri for which the diagnostics are reported.
+	URI string `json:"uri"`
+	// Version is the version number for which the diagnostics are reported
+	// or nil if the document is not open.
+	Version *int `json:"version"`
+}
+
+// MarshalJSON marshals the document report together with its uri and
+// version.
+func (r WorkspaceDocumentDiagnosticReport) MarshalJSON() ([]byte, error) {
+	report, err := json.Marshal(r.DocumentDiagnosticReport)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(report, &fields); err != nil {
+		return nil, err
+	}
+	if fields["uri"], err = json.Marshal(r.URI); err != nil {
+		return nil, err
+	}
+	if fields["version"], err = json.Marshal(r.Version); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
 }
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiagnosticCodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    DiagnosticCode
+		json    string
+		integer bool
+	}{
+		{"integer", NewIntegerDiagnosticCode(42), `42`, true},
+		{"negative integer", NewIntegerDiagnosticCode(-1), `-1`, true},
+		{"string", NewDiagnosticCode("E042"), `"E042"`, false},
+		{"numeric string", NewDiagnosticCode("42"), `"42"`, false},
+		{"empty string", DiagnosticCode{}, `""`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("Marshal = %s, want %s", data, tt.json)
+			}
+			var got DiagnosticCode
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.code {
+				t.Errorf("Unmarshal = %#v, want %#v", got, tt.code)
+			}
+			if _, integer := got.Int(); integer != tt.integer {
+				t.Errorf("Int reports integer %v, want %v", integer, tt.integer)
+			}
+		})
+	}
+}
+
+func TestDiagnosticCodeInvalidJSON(t *testing.T) {
+	for _, data := range []string{`1.5`, `true`, `{}`, `[1]`} {
+		var code DiagnosticCode
+		if err := json.Unmarshal([]byte(data), &code); err == nil {
+			t.Errorf("Unmarshal(%s) = %#v, want an error", data, code)
+		}
+	}
+}
+
+// TestDiagnosticRoundTrip sends a diagnostic to the client and decodes it
+// from the code action request the client sends it back in.
+func TestDiagnosticRoundTrip(t *testing.T) {
+	code := NewDiagnosticCode("unused")
+	want := Diagnostic{
+		Range:           Range{Start: Position{Line: 1}, End: Position{Line: 1, Character: 4}},
+		Severity:        DiagnosticWarning,
+		Code:            &code,
+		CodeDescription: &CodeDescription{Href: "https://example.com/unused"},
+		Source:          "vet",
+		Message:         "x is unused",
+		Tags:            []DiagnosticTag{DiagnosticTagUnnecessary},
+		RelatedInformation: []DiagnosticRelatedInformation{{
+			Location: Location{
+				URI:   "file:///a.go",
+				Range: Range{End: Position{Character: 1}},
+			},
+			Message: "declared here",
+		}},
+		Data: json.RawMessage(`{"fix":"remove","id":7}`),
+	}
+	published, err := json.Marshal(PublishDiagnosticsParams{
+		URI:         "file:///a.go",
+		Diagnostics: []Diagnostic{want},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var client struct {
+		Diagnostics []json.RawMessage `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(published, &client); err != nil {
+		t.Fatal(err)
+	}
+	request, err := json.Marshal(map[string]interface{}{
+		"textDocument": map[string]string{"uri": "file:///a.go"},
+		"range":        want.Range,
+		"context":      map[string]interface{}{"diagnostics": client.Diagnostics},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var params TextDocumentCodeActionParams
+	if err := DecodeParams(request, &params); err != nil {
+		t.Fatal(err)
+	}
+	if len(params.Context.Diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(params.Context.Diagnostics))
+	}
+	if got := params.Context.Diagnostics[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import "encoding/json"
+
+// MethodDocumentLinkResolve is the document link resolve request method
+// used to compute the target of a document link lazily.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#documentLink_resolve
+const MethodDocumentLinkResolve Method = "documentLink/resolve"
+
+// DocumentLinkRequest is a request to list the links of a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_documentLink
+type DocumentLinkRequest struct {
+	// DocumentLinkRequest embeds the Request struct
+	Request
+	// Params are the parameters for the document link request.
+	Params DocumentLinkParams `json:"params"`
+}
+
+// DocumentLinkParams are the parameters of a document link request.
+type DocumentLinkParams struct {
+	// TextDocument is the document to provide document links for.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentLinkResponse is the response for a document link request.
+type DocumentLinkResponse struct {
+	// DocumentLinkResponse embeds the Response struct
+	Response
+	// Result are the links of the document.
+	Result []DocumentLink `json:"result"`
+}
+
+// Method returns the method for the document link response
+func (r DocumentLinkResponse) Method() string {
+	return string(MethodTextDocumentDocumentLink)
+}
+
+// DocumentLink is a range in a text document that links to an internal
+// or external resource, like another text document or a web site.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#documentLink
+type DocumentLink struct {
+	// Range is the range this link applies to.
+	Range Range `json:"range"`
+	// Target is the uri this link points to. If missing a resolve request
+	// is sent later.
+	Target string `json:"target,omitempty"`
+	// Tooltip is the tooltip text when hovering over this link.
+	Tooltip string `json:"tooltip,omitempty"`
+	// Data is preserved between a document link request and a document
+	// link resolve request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// DocumentLinkResolveRequest is a request to resolve the target of a
+// document link.
+type DocumentLinkResolveRequest struct {
+	// DocumentLinkResolveRequest embeds the Request struct
+	Request
+	// Params is the document link to resolve.
+	Params DocumentLink `json:"params"`
+}
+
+// DocumentLinkResolveResponse is the response for a document link
+// resolve request.
+type DocumentLinkResolveResponse struct {
+	// DocumentLinkResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved document link.
+	Result DocumentLink `json:"result"`
+}
+
+// Method returns the method for the document link resolve response
+func (r DocumentLinkResolveResponse) Method() string {
+	return string(MethodDocumentLinkResolve)
+}
+
+// DocumentLinkOptions are the server capabilities for document links.
+type DocumentLinkOptions struct {
+	// ResolveProvider is whether the server provides support to resolve
+	// document links.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// DocumentLinkClientCapabilities are the client capabilities for document
+// links.
+type DocumentLinkClientCapabilities struct {
+	// DynamicRegistration is whether document link supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// TooltipSupport is whether the client supports the tooltip property.
+	TooltipSupport bool `json:"tooltipSupport,omitempty"`
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "strings"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // Request is a request to a language server.
 //
@@ -13,6 +16,8 @@ type Request struct {
 	ID int `json:"id,omitempty"`
 	// Method is the method for the request
 	Method string `json:"method"`
+	// Params are the raw parameters of the request
+	Params json.RawMessage `json:"params,omitempty"`
 }
 
 // Response is the response in an lanaguage server request.
@@ -37,6 +42,11 @@ type Error struct {
 	Data interface{} `json:"data,omitempty"`
 }
 
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
 // Notification is a notification from a LSP
 type Notification struct {
 	// RPC is the rpc method for the notification.
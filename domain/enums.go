@@ -0,0 +1,589 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// enumString returns the name of the enum value v where names holds the
+// names of the consecutive values starting at first.
+//
+// Values outside of the enum are formatted as typ(v) instead of panicking.
+func enumString(typ string, first int, names []string, v int) string {
+	if v < first || v-first >= len(names) {
+		return fmt.Sprintf("%s(%d)", typ, v)
+	}
+	return names[v-first]
+}
+
+// enumValid reports whether v is a value of the enum described by first
+// and names.
+func enumValid(first int, names []string, v int) bool {
+	return v >= first && v-first < len(names)
+}
+
+// enumParse returns the value of the enum described by first and names
+// with the given name ignoring case.
+func enumParse(typ string, first int, names []string, name string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return first + i, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid %s %q", typ, name)
+}
+
+// CompletionTriggerKind is an enum for how a completion was triggered.
+type CompletionTriggerKind int
+
+const (
+	// CompletionTriggerKindInvoked is a completion triggered by typing an
+	// identifier, manual invocation or via API.
+	CompletionTriggerKindInvoked CompletionTriggerKind = iota + 1
+	// CompletionTriggerKindTriggerCharacter is a completion triggered by a
+	// trigger character.
+	CompletionTriggerKindTriggerCharacter
+	// CompletionTriggerKindTriggerForIncompleteCompletions is a completion
+	// re-triggered as the current completion list is incomplete.
+	CompletionTriggerKindTriggerForIncompleteCompletions
+)
+
+var completionTriggerKindNames = []string{
+	"Invoked",
+	"TriggerCharacter",
+	"TriggerForIncompleteCompletions",
+}
+
+// String returns the string representation of the CompletionTriggerKind.
+func (k CompletionTriggerKind) String() string {
+	return enumString("CompletionTriggerKind", 1, completionTriggerKindNames, int(k))
+}
+
+// IsValid reports whether k is a known CompletionTriggerKind.
+func (k CompletionTriggerKind) IsValid() bool {
+	return enumValid(1, completionTriggerKindNames, int(k))
+}
+
+// ParseCompletionTriggerKind returns the CompletionTriggerKind with the
+// given name.
+func ParseCompletionTriggerKind(name string) (CompletionTriggerKind, error) {
+	v, err := enumParse("CompletionTriggerKind", 1, completionTriggerKindNames, name)
+	return CompletionTriggerKind(v), err
+}
+
+// InsertTextFormat is an enum for how the insert text of a completion item
+// is interpreted.
+type InsertTextFormat int
+
+const (
+	// InsertTextFormatPlainText is insert text inserted as plain text.
+	InsertTextFormatPlainText InsertTextFormat = iota + 1
+	// InsertTextFormatSnippet is insert text interpreted as a snippet.
+	InsertTextFormatSnippet
+)
+
+var insertTextFormatNames = []string{
+	"PlainText",
+	"Snippet",
+}
+
+// String returns the string representation of the InsertTextFormat.
+func (f InsertTextFormat) String() string {
+	return enumString("InsertTextFormat", 1, insertTextFormatNames, int(f))
+}
+
+// IsValid reports whether f is a known InsertTextFormat.
+func (f InsertTextFormat) IsValid() bool {
+	return enumValid(1, insertTextFormatNames, int(f))
+}
+
+// ParseInsertTextFormat returns the InsertTextFormat with the given name.
+func ParseInsertTextFormat(name string) (InsertTextFormat, error) {
+	v, err := enumParse("InsertTextFormat", 1, insertTextFormatNames, name)
+	return InsertTextFormat(v), err
+}
+
+// InsertTextMode is an enum for how whitespace and indentation is handled
+// during completion item insertion.
+type InsertTextMode int
+
+const (
+	// InsertTextModeAsIs inserts the text as is.
+	InsertTextModeAsIs InsertTextMode = iota + 1
+	// InsertTextModeAdjustIndentation adjusts the indentation of the
+	// inserted text to the line the item is accepted on.
+	InsertTextModeAdjustIndentation
+)
+
+var insertTextModeNames = []string{
+	"AsIs",
+	"AdjustIndentation",
+}
+
+// String returns the string representation of the InsertTextMode.
+func (m InsertTextMode) String() string {
+	return enumString("InsertTextMode", 1, insertTextModeNames, int(m))
+}
+
+// IsValid reports whether m is a known InsertTextMode.
+func (m InsertTextMode) IsValid() bool {
+	return enumValid(1, insertTextModeNames, int(m))
+}
+
+// ParseInsertTextMode returns the InsertTextMode with the given name.
+func ParseInsertTextMode(name string) (InsertTextMode, error) {
+	v, err := enumParse("InsertTextMode", 1, insertTextModeNames, name)
+	return InsertTextMode(v), err
+}
+
+// SymbolKind is an enum for symbol kinds.
+type SymbolKind int
+
+const (
+	// SymbolKindFile is a file symbol.
+	SymbolKindFile SymbolKind = iota + 1
+	// SymbolKindModule is a module symbol.
+	SymbolKindModule
+	// SymbolKindNamespace is a namespace symbol.
+	SymbolKindNamespace
+	// SymbolKindPackage is a package symbol.
+	SymbolKindPackage
+	// SymbolKindClass is a class symbol.
+	SymbolKindClass
+	// SymbolKindMethod is a method symbol.
+	SymbolKindMethod
+	// SymbolKindProperty is a property symbol.
+	SymbolKindProperty
+	// SymbolKindField is a field symbol.
+	SymbolKindField
+	// SymbolKindConstructor is a constructor symbol.
+	SymbolKindConstructor
+	// SymbolKindEnum is an enum symbol.
+	SymbolKindEnum
+	// SymbolKindInterface is an interface symbol.
+	SymbolKindInterface
+	// SymbolKindFunction is a function symbol.
+	SymbolKindFunction
+	// SymbolKindVariable is a variable symbol.
+	SymbolKindVariable
+	// SymbolKindConstant is a constant symbol.
+	SymbolKindConstant
+	// SymbolKindString is a string symbol.
+	SymbolKindString
+	// SymbolKindNumber is a number symbol.
+	SymbolKindNumber
+	// SymbolKindBoolean is a boolean symbol.
+	SymbolKindBoolean
+	// SymbolKindArray is an array symbol.
+	SymbolKindArray
+	// SymbolKindObject is an object symbol.
+	SymbolKindObject
+	// SymbolKindKey is a key symbol.
+	SymbolKindKey
+	// SymbolKindNull is a null symbol.
+	SymbolKindNull
+	// SymbolKindEnumMember is an enum member symbol.
+	SymbolKindEnumMember
+	// SymbolKindStruct is a struct symbol.
+	SymbolKindStruct
+	// SymbolKindEvent is an event symbol.
+	SymbolKindEvent
+	// SymbolKindOperator is an operator symbol.
+	SymbolKindOperator
+	// SymbolKindTypeParameter is a type parameter symbol.
+	SymbolKindTypeParameter
+)
+
+var symbolKindNames = []string{
+	"File",
+	"Module",
+	"Namespace",
+	"Package",
+	"Class",
+	"Method",
+	"Property",
+	"Field",
+	"Constructor",
+	"Enum",
+	"Interface",
+	"Function",
+	"Variable",
+	"Constant",
+	"String",
+	"Number",
+	"Boolean",
+	"Array",
+	"Object",
+	"Key",
+	"Null",
+	"EnumMember",
+	"Struct",
+	"Event",
+	"Operator",
+	"TypeParameter",
+}
+
+// String returns the string representation of the SymbolKind.
+func (k SymbolKind) String() string {
+	return enumString("SymbolKind", 1, symbolKindNames, int(k))
+}
+
+// IsValid reports whether k is a known SymbolKind.
+func (k SymbolKind) IsValid() bool {
+	return enumValid(1, symbolKindNames, int(k))
+}
+
+// ParseSymbolKind returns the SymbolKind with the given name.
+func ParseSymbolKind(name string) (SymbolKind, error) {
+	v, err := enumParse("SymbolKind", 1, symbolKindNames, name)
+	return SymbolKind(v), err
+}
+
+// SymbolTag is an enum for extra annotations of symbols.
+type SymbolTag int
+
+const (
+	// SymbolTagDeprecated renders a symbol as obsolete, usually using a
+	// strike-out.
+	SymbolTagDeprecated SymbolTag = iota + 1
+)
+
+var symbolTagNames = []string{
+	"Deprecated",
+}
+
+// String returns the string representation of the SymbolTag.
+func (t SymbolTag) String() string {
+	return enumString("SymbolTag", 1, symbolTagNames, int(t))
+}
+
+// IsValid reports whether t is a known SymbolTag.
+func (t SymbolTag) IsValid() bool {
+	return enumValid(1, symbolTagNames, int(t))
+}
+
+// ParseSymbolTag returns the SymbolTag with the given name.
+func ParseSymbolTag(name string) (SymbolTag, error) {
+	v, err := enumParse("SymbolTag", 1, symbolTagNames, name)
+	return SymbolTag(v), err
+}
+
+// MessageType is an enum for the type of a message shown to or logged for
+// the user.
+type MessageType int
+
+const (
+	// MessageTypeError is an error message.
+	MessageTypeError MessageType = iota + 1
+	// MessageTypeWarning is a warning message.
+	MessageTypeWarning
+	// MessageTypeInfo is an information message.
+	MessageTypeInfo
+	// MessageTypeLog is a log message.
+	MessageTypeLog
+)
+
+var messageTypeNames = []string{
+	"Error",
+	"Warning",
+	"Info",
+	"Log",
+}
+
+// String returns the string representation of the MessageType.
+func (t MessageType) String() string {
+	return enumString("MessageType", 1, messageTypeNames, int(t))
+}
+
+// IsValid reports whether t is a known MessageType.
+func (t MessageType) IsValid() bool {
+	return enumValid(1, messageTypeNames, int(t))
+}
+
+// ParseMessageType returns the MessageType with the given name.
+func ParseMessageType(name string) (MessageType, error) {
+	v, err := enumParse("MessageType", 1, messageTypeNames, name)
+	return MessageType(v), err
+}
+
+// DocumentHighlightKind is an enum for the kind of a document highlight.
+type DocumentHighlightKind int
+
+const (
+	// DocumentHighlightKindText is a textual occurrence.
+	DocumentHighlightKindText DocumentHighlightKind = iota + 1
+	// DocumentHighlightKindRead is a read-access of a symbol, like reading
+	// a variable.
+	DocumentHighlightKindRead
+	// DocumentHighlightKindWrite is a write-access of a symbol, like
+	// writing to a variable.
+	DocumentHighlightKindWrite
+)
+
+var documentHighlightKindNames = []string{
+	"Text",
+	"Read",
+	"Write",
+}
+
+// String returns the string representation of the DocumentHighlightKind.
+func (k DocumentHighlightKind) String() string {
+	return enumString("DocumentHighlightKind", 1, documentHighlightKindNames, int(k))
+}
+
+// IsValid reports whether k is a known DocumentHighlightKind.
+func (k DocumentHighlightKind) IsValid() bool {
+	return enumValid(1, documentHighlightKindNames, int(k))
+}
+
+// ParseDocumentHighlightKind returns the DocumentHighlightKind with the
+// given name.
+func ParseDocumentHighlightKind(name string) (DocumentHighlightKind, error) {
+	v, err := enumParse("DocumentHighlightKind", 1, documentHighlightKindNames, name)
+	return DocumentHighlightKind(v), err
+}
+
+// TextDocumentSyncKind is an enum for how text documents are synced.
+type TextDocumentSyncKind int
+
+const (
+	// TextDocumentSyncKindNone means documents should not be synced at all.
+	TextDocumentSyncKindNone TextDocumentSyncKind = iota
+	// TextDocumentSyncKindFull means documents are synced by always sending
+	// the full content of the document.
+	TextDocumentSyncKindFull
+	// TextDocumentSyncKindIncremental means documents are synced by sending
+	// the full content on open and incremental updates afterwards.
+	TextDocumentSyncKindIncremental
+)
+
+var textDocumentSyncKindNames = []string{
+	"None",
+	"Full",
+	"Incremental",
+}
+
+// String returns the string representation of the TextDocumentSyncKind.
+func (k TextDocumentSyncKind) String() string {
+	return enumString("TextDocumentSyncKind", 0, textDocumentSyncKindNames, int(k))
+}
+
+// IsValid reports whether k is a known TextDocumentSyncKind.
+func (k TextDocumentSyncKind) IsValid() bool {
+	return enumValid(0, textDocumentSyncKindNames, int(k))
+}
+
+// ParseTextDocumentSyncKind returns the TextDocumentSyncKind with the given
+// name.
+func ParseTextDocumentSyncKind(name string) (TextDocumentSyncKind, error) {
+	v, err := enumParse("TextDocumentSyncKind", 0, textDocumentSyncKindNames, name)
+	return TextDocumentSyncKind(v), err
+}
+
+// TextDocumentSaveReason is an enum for the reasons why a text document is
+// saved.
+type TextDocumentSaveReason int
+
+const (
+	// TextDocumentSaveReasonManual is a save triggered manually by the
+	// user.
+	TextDocumentSaveReasonManual TextDocumentSaveReason = iota + 1
+	// TextDocumentSaveReasonAfterDelay is an automatic save after a delay.
+	TextDocumentSaveReasonAfterDelay
+	// TextDocumentSaveReasonFocusOut is a save when the editor lost focus.
+	TextDocumentSaveReasonFocusOut
+)
+
+var textDocumentSaveReasonNames = []string{
+	"Manual",
+	"AfterDelay",
+	"FocusOut",
+}
+
+// String returns the string representation of the TextDocumentSaveReason.
+func (r TextDocumentSaveReason) String() string {
+	return enumString("TextDocumentSaveReason", 1, textDocumentSaveReasonNames, int(r))
+}
+
+// IsValid reports whether r is a known TextDocumentSaveReason.
+func (r TextDocumentSaveReason) IsValid() bool {
+	return enumValid(1, textDocumentSaveReasonNames, int(r))
+}
+
+// ParseTextDocumentSaveReason returns the TextDocumentSaveReason with the
+// given name.
+func ParseTextDocumentSaveReason(name string) (TextDocumentSaveReason, error) {
+	v, err := enumParse("TextDocumentSaveReason", 1, textDocumentSaveReasonNames, name)
+	return TextDocumentSaveReason(v), err
+}
+
+// FileChangeType is an enum for the types of file events.
+type FileChangeType int
+
+const (
+	// FileChangeTypeCreated is a created file.
+	FileChangeTypeCreated FileChangeType = iota + 1
+	// FileChangeTypeChanged is a changed file.
+	FileChangeTypeChanged
+	// FileChangeTypeDeleted is a deleted file.
+	FileChangeTypeDeleted
+)
+
+var fileChangeTypeNames = []string{
+	"Created",
+	"Changed",
+	"Deleted",
+}
+
+// String returns the string representation of the FileChangeType.
+func (t FileChangeType) String() string {
+	return enumString("FileChangeType", 1, fileChangeTypeNames, int(t))
+}
+
+// IsValid reports whether t is a known FileChangeType.
+func (t FileChangeType) IsValid() bool {
+	return enumValid(1, fileChangeTypeNames, int(t))
+}
+
+// ParseFileChangeType returns the FileChangeType with the given name.
+func ParseFileChangeType(name string) (FileChangeType, error) {
+	v, err := enumParse("FileChangeType", 1, fileChangeTypeNames, name)
+	return FileChangeType(v), err
+}
+
+// CodeActionTriggerKind is an enum for the reasons why code actions were
+// requested.
+type CodeActionTriggerKind int
+
+const (
+	// CodeActionTriggerKindInvoked is a code action explicitly requested
+	// by the user or by an extension.
+	CodeActionTriggerKindInvoked CodeActionTriggerKind = iota + 1
+	// CodeActionTriggerKindAutomatic is a code action requested
+	// automatically, e.g. when the selection changed.
+	CodeActionTriggerKindAutomatic
+)
+
+var codeActionTriggerKindNames = []string{
+	"Invoked",
+	"Automatic",
+}
+
+// String returns the string representation of the CodeActionTriggerKind.
+func (k CodeActionTriggerKind) String() string {
+	return enumString("CodeActionTriggerKind", 1, codeActionTriggerKindNames, int(k))
+}
+
+// IsValid reports whether k is a known CodeActionTriggerKind.
+func (k CodeActionTriggerKind) IsValid() bool {
+	return enumValid(1, codeActionTriggerKindNames, int(k))
+}
+
+// ParseCodeActionTriggerKind returns the CodeActionTriggerKind with the
+// given name.
+func ParseCodeActionTriggerKind(name string) (CodeActionTriggerKind, error) {
+	v, err := enumParse("CodeActionTriggerKind", 1, codeActionTriggerKindNames, name)
+	return CodeActionTriggerKind(v), err
+}
+
+// SignatureHelpTriggerKind is an enum for how signature help was
+// triggered.
+type SignatureHelpTriggerKind int
+
+const (
+	// SignatureHelpTriggerKindInvoked is signature help invoked manually by
+	// the user or by a command.
+	SignatureHelpTriggerKindInvoked SignatureHelpTriggerKind = iota + 1
+	// SignatureHelpTriggerKindTriggerCharacter is signature help triggered
+	// by a trigger character.
+	SignatureHelpTriggerKindTriggerCharacter
+	// SignatureHelpTriggerKindContentChange is signature help triggered by
+	// the cursor moving or by the document content changing.
+	SignatureHelpTriggerKindContentChange
+)
+
+var signatureHelpTriggerKindNames = []string{
+	"Invoked",
+	"TriggerCharacter",
+	"ContentChange",
+}
+
+// String returns the string representation of the SignatureHelpTriggerKind.
+func (k SignatureHelpTriggerKind) String() string {
+	return enumString("SignatureHelpTriggerKind", 1, signatureHelpTriggerKindNames, int(k))
+}
+
+// IsValid reports whether k is a known SignatureHelpTriggerKind.
+func (k SignatureHelpTriggerKind) IsValid() bool {
+	return enumValid(1, signatureHelpTriggerKindNames, int(k))
+}
+
+// ParseSignatureHelpTriggerKind returns the SignatureHelpTriggerKind with
+// the given name.
+func ParseSignatureHelpTriggerKind(name string) (SignatureHelpTriggerKind, error) {
+	v, err := enumParse("SignatureHelpTriggerKind", 1, signatureHelpTriggerKindNames, name)
+	return SignatureHelpTriggerKind(v), err
+}
+
+// InlayHintKind is an enum for the kinds of inlay hints.
+type InlayHintKind int
+
+const (
+	// InlayHintKindType is an inlay hint for a type annotation.
+	InlayHintKindType InlayHintKind = iota + 1
+	// InlayHintKindParameter is an inlay hint for a parameter.
+	InlayHintKindParameter
+)
+
+var inlayHintKindNames = []string{
+	"Type",
+	"Parameter",
+}
+
+// String returns the string representation of the InlayHintKind.
+func (k InlayHintKind) String() string {
+	return enumString("InlayHintKind", 1, inlayHintKindNames, int(k))
+}
+
+// IsValid reports whether k is a known InlayHintKind.
+func (k InlayHintKind) IsValid() bool {
+	return enumValid(1, inlayHintKindNames, int(k))
+}
+
+// ParseInlayHintKind returns the InlayHintKind with the given name.
+func ParseInlayHintKind(name string) (InlayHintKind, error) {
+	v, err := enumParse("InlayHintKind", 1, inlayHintKindNames, name)
+	return InlayHintKind(v), err
+}
+
+// PrepareSupportDefaultBehavior is an enum for the default behavior of a
+// client when a prepare rename request returns a default result.
+type PrepareSupportDefaultBehavior int
+
+const (
+	// PrepareSupportDefaultBehaviorIdentifier selects the identifier
+	// according to the rules of the language.
+	PrepareSupportDefaultBehaviorIdentifier PrepareSupportDefaultBehavior = iota + 1
+)
+
+var prepareSupportDefaultBehaviorNames = []string{
+	"Identifier",
+}
+
+// String returns the string representation of the
+// PrepareSupportDefaultBehavior.
+func (b PrepareSupportDefaultBehavior) String() string {
+	return enumString("PrepareSupportDefaultBehavior", 1, prepareSupportDefaultBehaviorNames, int(b))
+}
+
+// IsValid reports whether b is a known PrepareSupportDefaultBehavior.
+func (b PrepareSupportDefaultBehavior) IsValid() bool {
+	return enumValid(1, prepareSupportDefaultBehaviorNames, int(b))
+}
+
+// ParsePrepareSupportDefaultBehavior returns the
+// PrepareSupportDefaultBehavior with the given name.
+func ParsePrepareSupportDefaultBehavior(name string) (PrepareSupportDefaultBehavior, error) {
+	v, err := enumParse("PrepareSupportDefaultBehavior", 1, prepareSupportDefaultBehaviorNames, name)
+	return PrepareSupportDefaultBehavior(v), err
+}
@@ -0,0 +1,114 @@
+package domain
+
+import "testing"
+
+func TestEnumString(t *testing.T) {
+	tests := []struct {
+		name string
+		enum Enum
+		want string
+	}{
+		{"known", CompletionItemKindFunction, "Function"},
+		{"last", CompletionItemKindTypeParameter, "TypeParameter"},
+		{"zero", DiagnosticSeverity(0), "DiagnosticSeverity(0)"},
+		{"past the end", CompletionItemKind(99), "CompletionItemKind(99)"},
+		{"negative", SymbolKind(-1), "SymbolKind(-1)"},
+		{"zero based", TextDocumentSyncKindNone, "None"},
+		{"zero based past the end", TextDocumentSyncKind(3), "TextDocumentSyncKind(3)"},
+		{"single value", SymbolTag(2), "SymbolTag(2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.enum.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnumIsValid(t *testing.T) {
+	tests := []struct {
+		enum Enum
+		want bool
+	}{
+		{DiagnosticSeverity(0), false},
+		{DiagnosticError, true},
+		{DiagnosticHint, true},
+		{DiagnosticSeverity(5), false},
+		{TextDocumentSyncKindNone, true},
+		{TextDocumentSyncKindIncremental, true},
+		{TextDocumentSyncKind(-1), false},
+		{CompletionItemKind(25), true},
+		{CompletionItemKind(26), false},
+		{InsertTextModeAdjustIndentation, true},
+		{InsertTextMode(3), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.enum.String(), func(t *testing.T) {
+			if got := tt.enum.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func(name string) (Enum, error)
+		input   string
+		want    Enum
+		wantErr bool
+	}{
+		{
+			name:  "exact",
+			parse: func(s string) (Enum, error) { return ParseDiagnosticSeverity(s) },
+			input: "Warning",
+			want:  DiagnosticWarning,
+		},
+		{
+			name:  "ignores case",
+			parse: func(s string) (Enum, error) { return ParseSymbolKind(s) },
+			input: "typeparameter",
+			want:  SymbolKindTypeParameter,
+		},
+		{
+			name:  "zero based",
+			parse: func(s string) (Enum, error) { return ParseTextDocumentSyncKind(s) },
+			input: "none",
+			want:  TextDocumentSyncKindNone,
+		},
+		{
+			name:  "round trip",
+			parse: func(s string) (Enum, error) { return ParseCompletionItemKind(s) },
+			input: CompletionItemKindSnippet.String(),
+			want:  CompletionItemKindSnippet,
+		},
+		{
+			name:    "unknown",
+			parse:   func(s string) (Enum, error) { return ParseMessageType(s) },
+			input:   "Fatal",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			parse:   func(s string) (Enum, error) { return ParseInlayHintKind(s) },
+			input:   "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parsed %q as %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("parse %q = %v, %v, want %v", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
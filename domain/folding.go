@@ -0,0 +1,155 @@
+package domain
+
+// Folding and Selection Range Methods
+const (
+	// MethodTextDocumentFoldingRange is the folding range request method.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_foldingRange
+	MethodTextDocumentFoldingRange Method = "textDocument/foldingRange"
+
+	// MethodTextDocumentSelectionRange is the selection range request
+	// method.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_selectionRange
+	MethodTextDocumentSelectionRange Method = "textDocument/selectionRange"
+)
+
+// FoldingRangeKind is the kind of a folding range.
+type FoldingRangeKind string
+
+const (
+	// FoldingRangeKindComment folds a comment.
+	FoldingRangeKindComment FoldingRangeKind = "comment"
+	// FoldingRangeKindImports folds imports.
+	FoldingRangeKindImports FoldingRangeKind = "imports"
+	// FoldingRangeKindRegion folds a region, e.g. `#region`.
+	FoldingRangeKindRegion FoldingRangeKind = "region"
+)
+
+// FoldingRangeRequest is a request to list the folding ranges of a
+// document.
+type FoldingRangeRequest struct {
+	// FoldingRangeRequest embeds the Request struct
+	Request
+	// Params are the parameters for the folding range request.
+	Params FoldingRangeParams `json:"params"`
+}
+
+// FoldingRangeParams are the parameters of a folding range request.
+type FoldingRangeParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// FoldingRangeResponse is the response for a folding range request.
+type FoldingRangeResponse struct {
+	// FoldingRangeResponse embeds the Response struct
+	Response
+	// Result are the folding ranges of the document.
+	Result []FoldingRange `json:"result"`
+}
+
+// Method returns the method for the folding range response
+func (r FoldingRangeResponse) Method() string {
+	return string(MethodTextDocumentFoldingRange)
+}
+
+// FoldingRange is a range of lines which can be folded.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#foldingRange
+type FoldingRange struct {
+	// StartLine is the zero-based line where the folded range starts.
+	StartLine int `json:"startLine"`
+	// StartCharacter is the character where the folded range starts. It
+	// defaults to the end of the start line.
+	StartCharacter *int `json:"startCharacter,omitempty"`
+	// EndLine is the zero-based line where the folded range ends.
+	EndLine int `json:"endLine"`
+	// EndCharacter is the character where the folded range ends. It
+	// defaults to the end of the end line.
+	EndCharacter *int `json:"endCharacter,omitempty"`
+	// Kind is the kind of the folding range.
+	Kind FoldingRangeKind `json:"kind,omitempty"`
+	// CollapsedText is the text the client shows when the range is
+	// collapsed.
+	CollapsedText string `json:"collapsedText,omitempty"`
+}
+
+// FoldingRangeClientCapabilities are the client capabilities for folding
+// ranges.
+type FoldingRangeClientCapabilities struct {
+	// DynamicRegistration is whether folding ranges support dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// RangeLimit is the maximum number of folding ranges the client
+	// handles per document. Zero means no limit.
+	RangeLimit int `json:"rangeLimit,omitempty"`
+	// LineFoldingOnly is whether the client ignores the start and end
+	// characters of folding ranges.
+	LineFoldingOnly bool `json:"lineFoldingOnly,omitempty"`
+	// FoldingRangeKind are the folding range kinds supported by the
+	// client.
+	FoldingRangeKind struct {
+		// ValueSet are the folding range kinds supported by the client.
+		ValueSet []FoldingRangeKind `json:"valueSet,omitempty"`
+	} `json:"foldingRangeKind"`
+	// FoldingRange are the capabilities specific to folding ranges.
+	FoldingRange struct {
+		// CollapsedText is whether the client supports the collapsed
+		// text of folding ranges.
+		CollapsedText bool `json:"collapsedText,omitempty"`
+	} `json:"foldingRange"`
+}
+
+// SelectionRangeRequest is a request for the selection ranges around
+// positions of a document.
+type SelectionRangeRequest struct {
+	// SelectionRangeRequest embeds the Request struct
+	Request
+	// Params are the parameters for the selection range request.
+	Params SelectionRangeParams `json:"params"`
+}
+
+// SelectionRangeParams are the parameters of a selection range request.
+type SelectionRangeParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Positions are the positions inside the text document.
+	Positions []Position `json:"positions"`
+}
+
+// SelectionRangeResponse is the response for a selection range request.
+type SelectionRangeResponse struct {
+	// SelectionRangeResponse embeds the Response struct
+	Response
+	// Result are the selection ranges of the positions in the same order.
+	Result []SelectionRange `json:"result"`
+}
+
+// Method returns the method for the selection range response
+func (r SelectionRangeResponse) Method() string {
+	return string(MethodTextDocumentSelectionRange)
+}
+
+// SelectionRange is a range the selection can be expanded to, linked to
+// the next larger range.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#selectionRange
+type SelectionRange struct {
+	// Range is the range of this selection range.
+	Range Range `json:"range"`
+	// Parent is the parent selection range containing this range.
+	Parent *SelectionRange `json:"parent,omitempty"`
+}
+
+// SelectionRangeClientCapabilities are the client capabilities for
+// selection ranges.
+type SelectionRangeClientCapabilities struct {
+	// DynamicRegistration is whether selection ranges support dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
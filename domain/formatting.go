@@ -0,0 +1,150 @@
+package domain
+
+// FormattingOptions are the options of a formatting request.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#formattingOptions
+type FormattingOptions struct {
+	// TabSize is the size of a tab in spaces.
+	TabSize int `json:"tabSize"`
+	// InsertSpaces is whether to prefer spaces over tabs.
+	InsertSpaces bool `json:"insertSpaces"`
+	// TrimTrailingWhitespace is whether to trim trailing whitespace on a
+	// line.
+	TrimTrailingWhitespace bool `json:"trimTrailingWhitespace,omitempty"`
+	// InsertFinalNewline is whether to insert a newline character at the
+	// end of the file if one does not exist.
+	InsertFinalNewline bool `json:"insertFinalNewline,omitempty"`
+	// TrimFinalNewlines is whether to trim all newlines after the final
+	// newline at the end of the file.
+	TrimFinalNewlines bool `json:"trimFinalNewlines,omitempty"`
+}
+
+// DocumentFormattingRequest is a request to format a whole document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_formatting
+type DocumentFormattingRequest struct {
+	// DocumentFormattingRequest embeds the Request struct
+	Request
+	// Params are the parameters for the formatting request.
+	Params DocumentFormattingParams `json:"params"`
+}
+
+// DocumentFormattingParams are the parameters of a formatting request.
+type DocumentFormattingParams struct {
+	// TextDocument is the document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Options are the format options.
+	Options FormattingOptions `json:"options"`
+}
+
+// DocumentFormattingResponse is the response for a formatting request.
+type DocumentFormattingResponse struct {
+	// DocumentFormattingResponse embeds the Response struct
+	Response
+	// Result are the edits formatting the document.
+	Result []TextEdit `json:"result"`
+}
+
+// Method returns the method for the formatting response
+func (r DocumentFormattingResponse) Method() string {
+	return string(MethodTextDocumentFormatting)
+}
+
+// DocumentRangeFormattingRequest is a request to format a range of a
+// document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_rangeFormatting
+type DocumentRangeFormattingRequest struct {
+	// DocumentRangeFormattingRequest embeds the Request struct
+	Request
+	// Params are the parameters for the range formatting request.
+	Params DocumentRangeFormattingParams `json:"params"`
+}
+
+// DocumentRangeFormattingParams are the parameters of a range formatting
+// request.
+type DocumentRangeFormattingParams struct {
+	// TextDocument is the document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Range is the range to format.
+	Range Range `json:"range"`
+	// Options are the format options.
+	Options FormattingOptions `json:"options"`
+}
+
+// DocumentRangeFormattingResponse is the response for a range formatting
+// request.
+type DocumentRangeFormattingResponse struct {
+	// DocumentRangeFormattingResponse embeds the Response struct
+	Response
+	// Result are the edits formatting the range.
+	Result []TextEdit `json:"result"`
+}
+
+// Method returns the method for the range formatting response
+func (r DocumentRangeFormattingResponse) Method() string {
+	return string(MethodTextDocumentRangeFormatting)
+}
+
+// DocumentOnTypeFormattingRequest is a request to format a document while
+// typing.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_onTypeFormatting
+type DocumentOnTypeFormattingRequest struct {
+	// DocumentOnTypeFormattingRequest embeds the Request struct
+	Request
+	// Params are the parameters for the on type formatting request.
+	Params DocumentOnTypeFormattingParams `json:"params"`
+}
+
+// DocumentOnTypeFormattingParams are the parameters of an on type
+// formatting request.
+type DocumentOnTypeFormattingParams struct {
+	// TextDocument is the document to format.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Position is the position around which the on type formatting should
+	// happen. It is not necessarily the exact position where the
+	// character was typed.
+	Position Position `json:"position"`
+	// Ch is the character that has been typed that triggered the
+	// formatting request.
+	Ch string `json:"ch"`
+	// Options are the format options.
+	Options FormattingOptions `json:"options"`
+}
+
+// DocumentOnTypeFormattingResponse is the response for an on type
+// formatting request.
+type DocumentOnTypeFormattingResponse struct {
+	// DocumentOnTypeFormattingResponse embeds the Response struct
+	Response
+	// Result are the edits formatting the document.
+	Result []TextEdit `json:"result"`
+}
+
+// Method returns the method for the on type formatting response
+func (r DocumentOnTypeFormattingResponse) Method() string {
+	return string(MethodTextDocumentOnTypeFormatting)
+}
+
+// DocumentOnTypeFormattingOptions are the server capabilities for on type
+// formatting.
+type DocumentOnTypeFormattingOptions struct {
+	// FirstTriggerCharacter is a character on which formatting should be
+	// triggered, like `{`.
+	FirstTriggerCharacter string `json:"firstTriggerCharacter"`
+	// MoreTriggerCharacter are more trigger characters.
+	MoreTriggerCharacter []string `json:"moreTriggerCharacter,omitempty"`
+}
+
+// FormattingClientCapabilities are the client capabilities for document,
+// range and on type formatting.
+type FormattingClientCapabilities struct {
+	// DynamicRegistration is whether formatting supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
@@ -0,0 +1,305 @@
+package domain
+
+import "encoding/json"
+
+// Call and Type Hierarchy Methods
+const (
+	// MethodTextDocumentPrepareCallHierarchy is the request method
+	// returning the call hierarchy items at a position.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_prepareCallHierarchy
+	MethodTextDocumentPrepareCallHierarchy Method = "textDocument/prepareCallHierarchy"
+
+	// MethodCallHierarchyIncomingCalls is the request method returning the
+	// calls of a call hierarchy item.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#callHierarchy_incomingCalls
+	MethodCallHierarchyIncomingCalls Method = "callHierarchy/incomingCalls"
+
+	// MethodCallHierarchyOutgoingCalls is the request method returning the
+	// calls made by a call hierarchy item.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#callHierarchy_outgoingCalls
+	MethodCallHierarchyOutgoingCalls Method = "callHierarchy/outgoingCalls"
+
+	// MethodTextDocumentPrepareTypeHierarchy is the request method
+	// returning the type hierarchy items at a position.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_prepareTypeHierarchy
+	MethodTextDocumentPrepareTypeHierarchy Method = "textDocument/prepareTypeHierarchy"
+
+	// MethodTypeHierarchySupertypes is the request method returning the
+	// supertypes of a type hierarchy item.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#typeHierarchy_supertypes
+	MethodTypeHierarchySupertypes Method = "typeHierarchy/supertypes"
+
+	// MethodTypeHierarchySubtypes is the request method returning the
+	// subtypes of a type hierarchy item.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#typeHierarchy_subtypes
+	MethodTypeHierarchySubtypes Method = "typeHierarchy/subtypes"
+)
+
+// CallHierarchyItem is an item of the call hierarchy, like a function.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#callHierarchyItem
+type CallHierarchyItem struct {
+	// Name is the name of this item.
+	Name string `json:"name"`
+	// Kind is the kind of this item.
+	Kind SymbolKind `json:"kind"`
+	// Tags are the tags of this item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+	// Detail is more detail for this item, e.g. the signature of a
+	// function.
+	Detail string `json:"detail,omitempty"`
+	// URI is the resource identifier of this item.
+	URI string `json:"uri"`
+	// Range encloses this symbol not including leading and trailing
+	// whitespace but everything else, e.g. comments and code.
+	Range Range `json:"range"`
+	// SelectionRange is the range that should be selected and revealed
+	// when this symbol is picked, e.g. the name of a function.
+	SelectionRange Range `json:"selectionRange"`
+	// Data is preserved between a prepare request and the follow-up
+	// requests for this item.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// CallHierarchyPrepareParams are the parameters of a prepare call
+// hierarchy request.
+type CallHierarchyPrepareParams struct {
+	// TextDocumentPositionParams is the position of the item.
+	TextDocumentPositionParams
+}
+
+// CallHierarchyIncomingCallsParams are the parameters of an incoming calls
+// request.
+type CallHierarchyIncomingCallsParams struct {
+	// Item is the item returned by a prepare request.
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyIncomingCall is a call to an item.
+type CallHierarchyIncomingCall struct {
+	// From is the item that makes the call.
+	From CallHierarchyItem `json:"from"`
+	// FromRanges are the ranges at which the calls appear, relative to
+	// the caller denoted by From.
+	FromRanges []Range `json:"fromRanges"`
+}
+
+// CallHierarchyOutgoingCallsParams are the parameters of an outgoing calls
+// request.
+type CallHierarchyOutgoingCallsParams struct {
+	// Item is the item returned by a prepare request.
+	Item CallHierarchyItem `json:"item"`
+}
+
+// CallHierarchyOutgoingCall is a call made by an item.
+type CallHierarchyOutgoingCall struct {
+	// To is the item that is called.
+	To CallHierarchyItem `json:"to"`
+	// FromRanges are the ranges at which the item is called, relative to
+	// the caller of the outgoing calls request.
+	FromRanges []Range `json:"fromRanges"`
+}
+
+// CallHierarchyPrepareRequest is a request for the call hierarchy items at
+// a position.
+type CallHierarchyPrepareRequest struct {
+	// CallHierarchyPrepareRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params CallHierarchyPrepareParams `json:"params"`
+}
+
+// CallHierarchyPrepareResponse is the response for a prepare call hierarchy
+// request.
+type CallHierarchyPrepareResponse struct {
+	// CallHierarchyPrepareResponse embeds the Response struct
+	Response
+	// Result are the items at the position.
+	Result []CallHierarchyItem `json:"result"`
+}
+
+// Method returns the method for the prepare call hierarchy response
+func (r CallHierarchyPrepareResponse) Method() string {
+	return string(MethodTextDocumentPrepareCallHierarchy)
+}
+
+// CallHierarchyIncomingCallsRequest is a request for the calls of a call
+// hierarchy item.
+type CallHierarchyIncomingCallsRequest struct {
+	// CallHierarchyIncomingCallsRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params CallHierarchyIncomingCallsParams `json:"params"`
+}
+
+// CallHierarchyIncomingCallsResponse is the response for an incoming calls
+// request.
+type CallHierarchyIncomingCallsResponse struct {
+	// CallHierarchyIncomingCallsResponse embeds the Response struct
+	Response
+	// Result are the calls of the item.
+	Result []CallHierarchyIncomingCall `json:"result"`
+}
+
+// Method returns the method for the incoming calls response
+func (r CallHierarchyIncomingCallsResponse) Method() string {
+	return string(MethodCallHierarchyIncomingCalls)
+}
+
+// CallHierarchyOutgoingCallsRequest is a request for the calls made by a
+// call hierarchy item.
+type CallHierarchyOutgoingCallsRequest struct {
+	// CallHierarchyOutgoingCallsRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params CallHierarchyOutgoingCallsParams `json:"params"`
+}
+
+// CallHierarchyOutgoingCallsResponse is the response for an outgoing calls
+// request.
+type CallHierarchyOutgoingCallsResponse struct {
+	// CallHierarchyOutgoingCallsResponse embeds the Response struct
+	Response
+	// Result are the calls made by the item.
+	Result []CallHierarchyOutgoingCall `json:"result"`
+}
+
+// Method returns the method for the outgoing calls response
+func (r CallHierarchyOutgoingCallsResponse) Method() string {
+	return string(MethodCallHierarchyOutgoingCalls)
+}
+
+// TypeHierarchyItem is an item of the type hierarchy, like a class.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#typeHierarchyItem
+type TypeHierarchyItem struct {
+	// Name is the name of this item.
+	Name string `json:"name"`
+	// Kind is the kind of this item.
+	Kind SymbolKind `json:"kind"`
+	// Tags are the tags of this item.
+	Tags []SymbolTag `json:"tags,omitempty"`
+	// Detail is more detail for this item, e.g. the signature of a
+	// function.
+	Detail string `json:"detail,omitempty"`
+	// URI is the resource identifier of this item.
+	URI string `json:"uri"`
+	// Range encloses this symbol not including leading and trailing
+	// whitespace but everything else, e.g. comments and code.
+	Range Range `json:"range"`
+	// SelectionRange is the range that should be selected and revealed
+	// when this symbol is picked, e.g. the name of a function.
+	SelectionRange Range `json:"selectionRange"`
+	// Data is preserved between a prepare request and the follow-up
+	// requests for this item.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// TypeHierarchyPrepareParams are the parameters of a prepare type
+// hierarchy request.
+type TypeHierarchyPrepareParams struct {
+	// TextDocumentPositionParams is the position of the item.
+	TextDocumentPositionParams
+}
+
+// TypeHierarchySupertypesParams are the parameters of a supertypes
+// request.
+type TypeHierarchySupertypesParams struct {
+	// Item is the item returned by a prepare request.
+	Item TypeHierarchyItem `json:"item"`
+}
+
+// TypeHierarchySubtypesParams are the parameters of a subtypes request.
+type TypeHierarchySubtypesParams struct {
+	// Item is the item returned by a prepare request.
+	Item TypeHierarchyItem `json:"item"`
+}
+
+// TypeHierarchyPrepareRequest is a request for the type hierarchy items at
+// a position.
+type TypeHierarchyPrepareRequest struct {
+	// TypeHierarchyPrepareRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params TypeHierarchyPrepareParams `json:"params"`
+}
+
+// TypeHierarchyPrepareResponse is the response for a prepare type hierarchy
+// request.
+type TypeHierarchyPrepareResponse struct {
+	// TypeHierarchyPrepareResponse embeds the Response struct
+	Response
+	// Result are the items at the position.
+	Result []TypeHierarchyItem `json:"result"`
+}
+
+// Method returns the method for the prepare type hierarchy response
+func (r TypeHierarchyPrepareResponse) Method() string {
+	return string(MethodTextDocumentPrepareTypeHierarchy)
+}
+
+// TypeHierarchySupertypesRequest is a request for the supertypes of a type
+// hierarchy item.
+type TypeHierarchySupertypesRequest struct {
+	// TypeHierarchySupertypesRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params TypeHierarchySupertypesParams `json:"params"`
+}
+
+// TypeHierarchySupertypesResponse is the response for a supertypes request.
+type TypeHierarchySupertypesResponse struct {
+	// TypeHierarchySupertypesResponse embeds the Response struct
+	Response
+	// Result are the supertypes of the item.
+	Result []TypeHierarchyItem `json:"result"`
+}
+
+// Method returns the method for the supertypes response
+func (r TypeHierarchySupertypesResponse) Method() string {
+	return string(MethodTypeHierarchySupertypes)
+}
+
+// TypeHierarchySubtypesRequest is a request for the subtypes of a type
+// hierarchy item.
+type TypeHierarchySubtypesRequest struct {
+	// TypeHierarchySubtypesRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params TypeHierarchySubtypesParams `json:"params"`
+}
+
+// TypeHierarchySubtypesResponse is the response for a subtypes request.
+type TypeHierarchySubtypesResponse struct {
+	// TypeHierarchySubtypesResponse embeds the Response struct
+	Response
+	// Result are the subtypes of the item.
+	Result []TypeHierarchyItem `json:"result"`
+}
+
+// Method returns the method for the subtypes response
+func (r TypeHierarchySubtypesResponse) Method() string {
+	return string(MethodTypeHierarchySubtypes)
+}
+
+// HierarchyClientCapabilities are the client capabilities for call and
+// type hierarchies.
+type HierarchyClientCapabilities struct {
+	// DynamicRegistration is whether the hierarchy supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
@@ -1,5 +1,12 @@
 package domain
 
+// MethodInitialize is the initialize request method sent as the first
+// request from the client to the server.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#initialize
+const MethodInitialize Method = "initialize"
+
 // InitializeRequest is a struct for the initialize request.
 type InitializeRequest struct {
 	// InitializeRequest embeds the Request struct
@@ -16,6 +23,8 @@ type InitializeRequestParams struct {
 	RootPath string `json:"rootPath,omitempty"`
 	// Trace is the trace of the client in the request
 	Trace string `json:"trace,omitempty"`
+	// Capabilities are the capabilities provided by the client.
+	Capabilities ClientCapabilities `json:"capabilities"`
 }
 
 // ClientInfo is a struct for the client info
@@ -39,7 +48,7 @@ type InitializeResponse struct {
 
 // Method returns the method for the initialize response
 func (r InitializeResponse) Method() string {
-	return "initialize"
+	return string(MethodInitialize)
 }
 
 // InitializeResult is a struct for the initialize result used in the initialize response.
@@ -52,16 +61,70 @@ type InitializeResult struct {
 
 // ServerCapabilities is a struct for the server capabilities
 type ServerCapabilities struct {
+	// PositionEncoding is the position encoding the server picked from the
+	// encodings offered by the client. It defaults to UTF-16.
+	PositionEncoding PositionEncodingKind `json:"positionEncoding,omitempty"`
 	// TextDocumentSync is what the server supports for syncing text documents.
 	TextDocumentSync int `json:"textDocumentSync"`
 	// HoverProvider is a boolean indicating whether the server provides.
 	HoverProvider bool `json:"hoverProvider"`
 	// DefinitionProvider is a boolean indicating whether the server provides definition capabilities.
 	DefinitionProvider bool `json:"definitionProvider"`
-	// CodeActionProvider is a boolean indicating whether the server provides code actions.
-	CodeActionProvider bool `json:"codeActionProvider"`
-	// CompletionProvider is a map of completion providers.
-	CompletionProvider map[string]any `json:"completionProvider"`
+	// DeclarationProvider is a boolean indicating whether the server provides declaration capabilities.
+	DeclarationProvider bool `json:"declarationProvider,omitempty"`
+	// TypeDefinitionProvider is a boolean indicating whether the server provides type definition capabilities.
+	TypeDefinitionProvider bool `json:"typeDefinitionProvider,omitempty"`
+	// ImplementationProvider is a boolean indicating whether the server provides implementation capabilities.
+	ImplementationProvider bool `json:"implementationProvider,omitempty"`
+	// ReferencesProvider is a boolean indicating whether the server provides references.
+	ReferencesProvider bool `json:"referencesProvider,omitempty"`
+	// DocumentHighlightProvider is a boolean indicating whether the server provides document highlights.
+	DocumentHighlightProvider bool `json:"documentHighlightProvider,omitempty"`
+	// DocumentSymbolProvider is a boolean indicating whether the server provides document symbols.
+	DocumentSymbolProvider bool `json:"documentSymbolProvider,omitempty"`
+	// WorkspaceSymbolProvider are the workspace symbol capabilities of the server.
+	WorkspaceSymbolProvider *WorkspaceSymbolOptions `json:"workspaceSymbolProvider,omitempty"`
+	// DocumentFormattingProvider is a boolean indicating whether the server provides document formatting.
+	DocumentFormattingProvider bool `json:"documentFormattingProvider,omitempty"`
+	// DocumentRangeFormattingProvider is a boolean indicating whether the server provides range formatting.
+	DocumentRangeFormattingProvider bool `json:"documentRangeFormattingProvider,omitempty"`
+	// DocumentOnTypeFormattingProvider are the on type formatting capabilities of the server.
+	DocumentOnTypeFormattingProvider *DocumentOnTypeFormattingOptions `json:"documentOnTypeFormattingProvider,omitempty"`
+	// RenameProvider is either a boolean indicating whether the server
+	// provides renames or the RenameOptions of the server.
+	RenameProvider interface{} `json:"renameProvider,omitempty"`
+	// CodeLensProvider are the code lens capabilities of the server.
+	CodeLensProvider *CodeLensOptions `json:"codeLensProvider,omitempty"`
+	// DocumentLinkProvider are the document link capabilities of the server.
+	DocumentLinkProvider *DocumentLinkOptions `json:"documentLinkProvider,omitempty"`
+	// SemanticTokensProvider are the semantic tokens capabilities of the server.
+	SemanticTokensProvider *SemanticTokensOptions `json:"semanticTokensProvider,omitempty"`
+	// InlayHintProvider are the inlay hint capabilities of the server.
+	InlayHintProvider *InlayHintOptions `json:"inlayHintProvider,omitempty"`
+	// FoldingRangeProvider is a boolean indicating whether the server provides folding ranges.
+	FoldingRangeProvider bool `json:"foldingRangeProvider,omitempty"`
+	// SelectionRangeProvider is a boolean indicating whether the server provides selection ranges.
+	SelectionRangeProvider bool `json:"selectionRangeProvider,omitempty"`
+	// CallHierarchyProvider is a boolean indicating whether the server provides call hierarchies.
+	CallHierarchyProvider bool `json:"callHierarchyProvider,omitempty"`
+	// TypeHierarchyProvider is a boolean indicating whether the server provides type hierarchies.
+	TypeHierarchyProvider bool `json:"typeHierarchyProvider,omitempty"`
+	// ColorProvider is a boolean indicating whether the server provides document colors.
+	ColorProvider bool `json:"colorProvider,omitempty"`
+	// LinkedEditingRangeProvider is a boolean indicating whether the server provides linked editing ranges.
+	LinkedEditingRangeProvider bool `json:"linkedEditingRangeProvider,omitempty"`
+	// ExecuteCommandProvider are the commands executed by the server.
+	ExecuteCommandProvider *ExecuteCommandOptions `json:"executeCommandProvider,omitempty"`
+	// CodeActionProvider is either a boolean indicating whether the server
+	// provides code actions or the CodeActionOptions of the server.
+	CodeActionProvider BoolOrCodeActionOptions `json:"codeActionProvider"`
+	// CompletionProvider are the completion capabilities of the server.
+	CompletionProvider *CompletionOptions `json:"completionProvider,omitempty"`
+	// SignatureHelpProvider are the signature help capabilities of the
+	// server.
+	SignatureHelpProvider *SignatureHelpOptions `json:"signatureHelpProvider,omitempty"`
+	// DiagnosticProvider are the pull diagnostic capabilities of the server.
+	DiagnosticProvider *DiagnosticOptions `json:"diagnosticProvider,omitempty"`
 }
 
 // ServerInfo is a struct for the server info.
@@ -84,8 +147,8 @@ func NewInitializeResponse(id int) InitializeResponse {
 				TextDocumentSync:   1,
 				HoverProvider:      true,
 				DefinitionProvider: true,
-				CodeActionProvider: true,
-				CompletionProvider: map[string]any{},
+				CodeActionProvider: BoolOrCodeActionOptions{Enabled: true},
+				CompletionProvider: &CompletionOptions{},
 			},
 			ServerInfo: ServerInfo{
 				Name:    "seltabl_lsp",
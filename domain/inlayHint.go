@@ -0,0 +1,189 @@
+package domain
+
+import "encoding/json"
+
+// Inlay Hint Methods
+const (
+	// MethodTextDocumentInlayHint is the inlay hint request method used to
+	// list the inlay hints of a range of a document.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_inlayHint
+	MethodTextDocumentInlayHint Method = "textDocument/inlayHint"
+
+	// MethodInlayHintResolve is the inlay hint resolve request method used
+	// to compute the tooltip, location or command of an inlay hint lazily.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#inlayHint_resolve
+	MethodInlayHintResolve Method = "inlayHint/resolve"
+
+	// MethodWorkspaceInlayHintRefresh is the request sent from the server
+	// to the client asking it to refresh all inlay hints.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_inlayHint_refresh
+	MethodWorkspaceInlayHintRefresh Method = "workspace/inlayHint/refresh"
+)
+
+// InlayHintRequest is a request to list the inlay hints of a range of a
+// document.
+type InlayHintRequest struct {
+	// InlayHintRequest embeds the Request struct
+	Request
+	// Params are the parameters for the inlay hint request.
+	Params InlayHintParams `json:"params"`
+}
+
+// InlayHintParams are the parameters of an inlay hint request.
+type InlayHintParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Range is the visible document range for which inlay hints should be
+	// computed.
+	Range Range `json:"range"`
+}
+
+// InlayHintResponse is the response for an inlay hint request.
+type InlayHintResponse struct {
+	// InlayHintResponse embeds the Response struct
+	Response
+	// Result are the inlay hints of the range.
+	Result []InlayHint `json:"result"`
+}
+
+// Method returns the method for the inlay hint response
+func (r InlayHintResponse) Method() string {
+	return string(MethodTextDocumentInlayHint)
+}
+
+// InlayHint is additional information rendered inline with the source
+// text, like the name of a parameter or an inferred type.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#inlayHint
+type InlayHint struct {
+	// Position is the position of this hint.
+	Position Position `json:"position"`
+	// Label is the label of this hint.
+	Label InlayHintLabel `json:"label"`
+	// Kind is the kind of this hint.
+	Kind InlayHintKind `json:"kind,omitempty"`
+	// TextEdits are the edits performed when accepting this hint.
+	TextEdits []TextEdit `json:"textEdits,omitempty"`
+	// Tooltip is the tooltip text when hovering over this hint.
+	Tooltip *MarkupContent `json:"tooltip,omitempty"`
+	// PaddingLeft renders padding before the hint.
+	PaddingLeft bool `json:"paddingLeft,omitempty"`
+	// PaddingRight renders padding after the hint.
+	PaddingRight bool `json:"paddingRight,omitempty"`
+	// Data is preserved between an inlay hint request and an inlay hint
+	// resolve request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// InlayHintLabel is the label of an inlay hint given either as a text or
+// as parts.
+type InlayHintLabel struct {
+	// Text is the label as a text.
+	Text string
+	// Parts is the label as parts. It is used instead of Text if set.
+	Parts []InlayHintLabelPart
+}
+
+// NewInlayHintLabel creates a label from parts.
+func NewInlayHintLabel(parts ...InlayHintLabelPart) InlayHintLabel {
+	return InlayHintLabel{Parts: parts}
+}
+
+// String returns the text of the label joining the values of its parts.
+func (l InlayHintLabel) String() string {
+	if l.Parts == nil {
+		return l.Text
+	}
+	text := ""
+	for _, part := range l.Parts {
+		text += part.Value
+	}
+	return text
+}
+
+// MarshalJSON marshals the label as its parts or its text.
+func (l InlayHintLabel) MarshalJSON() ([]byte, error) {
+	if l.Parts != nil {
+		return json.Marshal(l.Parts)
+	}
+	return json.Marshal(l.Text)
+}
+
+// UnmarshalJSON unmarshals the label from parts or a text.
+func (l *InlayHintLabel) UnmarshalJSON(data []byte) error {
+	var parts []InlayHintLabelPart
+	if err := json.Unmarshal(data, &parts); err == nil {
+		*l = InlayHintLabel{Parts: parts}
+		return nil
+	}
+	*l = InlayHintLabel{}
+	return json.Unmarshal(data, &l.Text)
+}
+
+// InlayHintLabelPart is a part of the label of an inlay hint which can be
+// interacted with.
+type InlayHintLabelPart struct {
+	// Value is the text of the part.
+	Value string `json:"value"`
+	// Tooltip is the tooltip text when hovering over the part.
+	Tooltip *MarkupContent `json:"tooltip,omitempty"`
+	// Location is the source code location the part links to, e.g. the
+	// definition of an inferred type.
+	Location *Location `json:"location,omitempty"`
+	// Command is the command run when clicking the part.
+	Command *Command `json:"command,omitempty"`
+}
+
+// InlayHintResolveRequest is a request to resolve an inlay hint.
+type InlayHintResolveRequest struct {
+	// InlayHintResolveRequest embeds the Request struct
+	Request
+	// Params is the inlay hint to resolve.
+	Params InlayHint `json:"params"`
+}
+
+// InlayHintResolveResponse is the response for an inlay hint resolve
+// request.
+type InlayHintResolveResponse struct {
+	// InlayHintResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved inlay hint.
+	Result InlayHint `json:"result"`
+}
+
+// Method returns the method for the inlay hint resolve response
+func (r InlayHintResolveResponse) Method() string {
+	return string(MethodInlayHintResolve)
+}
+
+// InlayHintOptions are the server capabilities for inlay hints.
+type InlayHintOptions struct {
+	// ResolveProvider is whether the server provides support to resolve
+	// inlay hints.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// InlayHintClientCapabilities are the client capabilities for inlay
+// hints.
+type InlayHintClientCapabilities struct {
+	// DynamicRegistration is whether inlay hints support dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// ResolveSupport are the properties the client can resolve lazily.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// InlayHintWorkspaceClientCapabilities are the client capabilities for
+// inlay hints in the workspace.
+type InlayHintWorkspaceClientCapabilities struct {
+	// RefreshSupport is whether the client supports the
+	// workspace/inlayHint/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarkupKind describes the content type of a MarkupContent.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#markupContent
+type MarkupKind string
+
+const (
+	// MarkupKindPlainText is plain text content.
+	MarkupKindPlainText MarkupKind = "plaintext"
+	// MarkupKindMarkdown is markdown content.
+	MarkupKindMarkdown MarkupKind = "markdown"
+)
+
+// MarkupContent is a string value with a content kind which is used by
+// the client to render it.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#markupContent
+type MarkupContent struct {
+	// Kind is the type of the markup.
+	Kind MarkupKind `json:"kind"`
+	// Value is the content itself.
+	Value string `json:"value"`
+}
+
+// StringOrMarkupContent is documentation which is either a plain string or
+// MarkupContent.
+type StringOrMarkupContent struct {
+	// Value is the plain string documentation used if Markup is nil.
+	Value string
+	// Markup is the markup documentation sent instead of Value if set.
+	Markup *MarkupContent
+}
+
+// NewStringDocumentation creates plain string documentation.
+func NewStringDocumentation(value string) *StringOrMarkupContent {
+	return &StringOrMarkupContent{Value: value}
+}
+
+// NewMarkupDocumentation creates markup documentation.
+func NewMarkupDocumentation(markup MarkupContent) *StringOrMarkupContent {
+	return &StringOrMarkupContent{Markup: &markup}
+}
+
+// String returns the documentation text regardless of its kind.
+func (d StringOrMarkupContent) String() string {
+	if d.Markup != nil {
+		return d.Markup.Value
+	}
+	return d.Value
+}
+
+// MarshalJSON marshals the documentation as markup content if set,
+// otherwise as a string.
+func (d StringOrMarkupContent) MarshalJSON() ([]byte, error) {
+	if d.Markup != nil {
+		return json.Marshal(d.Markup)
+	}
+	return json.Marshal(d.Value)
+}
+
+// UnmarshalJSON unmarshals the documentation from a string or markup
+// content.
+func (d *StringOrMarkupContent) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*d = StringOrMarkupContent{Value: value}
+		return nil
+	}
+	var markup MarkupContent
+	if err := json.Unmarshal(data, &markup); err != nil {
+		return fmt.Errorf("documentation must be a string or markup content: %w", err)
+	}
+	*d = StringOrMarkupContent{Markup: &markup}
+	return nil
+}
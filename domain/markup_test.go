@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStringOrMarkupContent(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  *StringOrMarkupContent
+		want string
+	}{
+		{"string", NewStringDocumentation("plain"), `"plain"`},
+		{
+			"markup",
+			NewMarkupDocumentation(MarkupContent{Kind: MarkupKindMarkdown, Value: "*md*"}),
+			`{"kind":"markdown","value":"*md*"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(CompletionItem{Label: "x", Documentation: tt.doc})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `{"label":"x","documentation":` + tt.want + `}`
+			if string(data) != want {
+				t.Errorf("marshal = %s, want %s", data, want)
+			}
+			var item CompletionItem
+			if err := json.Unmarshal(data, &item); err != nil {
+				t.Fatal(err)
+			}
+			if item.Documentation == nil ||
+				item.Documentation.String() != tt.doc.String() ||
+				(item.Documentation.Markup == nil) != (tt.doc.Markup == nil) {
+				t.Errorf("unmarshal = %+v, want %+v", item.Documentation, tt.doc)
+			}
+		})
+	}
+}
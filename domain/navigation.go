@@ -0,0 +1,147 @@
+package domain
+
+// Navigation Request Methods
+const (
+	// MethodRequestTextDocumentDeclaration is the text document declaration
+	// method for the LSP
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_declaration
+	MethodRequestTextDocumentDeclaration Method = "textDocument/declaration"
+
+	// MethodRequestTextDocumentTypeDefinition is the text document type
+	// definition method for the LSP
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_typeDefinition
+	MethodRequestTextDocumentTypeDefinition Method = "textDocument/typeDefinition"
+
+	// MethodRequestTextDocumentImplementation is the text document
+	// implementation method for the LSP
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_implementation
+	MethodRequestTextDocumentImplementation Method = "textDocument/implementation"
+)
+
+// DefinitionRequest is a request to resolve the definition location of a
+// symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_definition
+type DefinitionRequest struct {
+	// DefinitionRequest embeds the Request struct
+	Request
+	// Params are the parameters for the definition request.
+	Params DefinitionParams `json:"params"`
+}
+
+// DefinitionParams are the parameters of a definition request.
+type DefinitionParams struct {
+	// DefinitionParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+}
+
+// DeclarationRequest is a request to resolve the declaration location of a
+// symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_declaration
+type DeclarationRequest struct {
+	// DeclarationRequest embeds the Request struct
+	Request
+	// Params are the parameters for the declaration request.
+	Params DeclarationParams `json:"params"`
+}
+
+// DeclarationParams are the parameters of a declaration request.
+type DeclarationParams struct {
+	// DeclarationParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+}
+
+// TypeDefinitionRequest is a request to resolve the type definition
+// location of a symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_typeDefinition
+type TypeDefinitionRequest struct {
+	// TypeDefinitionRequest embeds the Request struct
+	Request
+	// Params are the parameters for the type definition request.
+	Params TypeDefinitionParams `json:"params"`
+}
+
+// TypeDefinitionParams are the parameters of a type definition request.
+type TypeDefinitionParams struct {
+	// TypeDefinitionParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+}
+
+// ImplementationRequest is a request to resolve the implementation
+// locations of a symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_implementation
+type ImplementationRequest struct {
+	// ImplementationRequest embeds the Request struct
+	Request
+	// Params are the parameters for the implementation request.
+	Params ImplementationParams `json:"params"`
+}
+
+// ImplementationParams are the parameters of an implementation request.
+type ImplementationParams struct {
+	// ImplementationParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+}
+
+// LocationResponse is the response for a definition, declaration, type
+// definition or implementation request.
+type LocationResponse struct {
+	// LocationResponse embeds the Response struct
+	Response
+	// Result is either a []Location or a []LocationLink.
+	Result interface{} `json:"result"`
+}
+
+// LocationLink represents a link between a source and a target location.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#locationLink
+type LocationLink struct {
+	// OriginSelectionRange is the span of the origin of this link, e.g.
+	// the word under the mouse. It defaults to the word range at the
+	// position of the request.
+	OriginSelectionRange *Range `json:"originSelectionRange,omitempty"`
+	// TargetURI is the target resource identifier of this link.
+	TargetURI string `json:"targetUri"`
+	// TargetRange is the full target range of this link, e.g. the whole
+	// definition of a symbol including its body and comments.
+	TargetRange Range `json:"targetRange"`
+	// TargetSelectionRange is the range that should be selected and
+	// revealed when this link is followed, e.g. the name of a function. It
+	// must be contained in the target range.
+	TargetSelectionRange Range `json:"targetSelectionRange"`
+}
+
+// Location returns the location the link targets.
+//
+// The location covers the target selection range of the link.
+func (l LocationLink) Location() Location {
+	return Location{
+		URI:   l.TargetURI,
+		Range: l.TargetSelectionRange,
+	}
+}
+
+// DefinitionClientCapabilities are the client capabilities for definition,
+// declaration, type definition and implementation requests.
+type DefinitionClientCapabilities struct {
+	// DynamicRegistration is whether the request supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// LinkSupport is whether the client supports additional metadata in
+	// the form of location links.
+	LinkSupport bool `json:"linkSupport,omitempty"`
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// PositionEncodingKind is the encoding in which the character offsets of
+// positions are counted.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#positionEncodingKind
+type PositionEncodingKind string
+
+const (
+	// PositionEncodingUTF8 counts characters in UTF-8 code units, i.e.
+	// bytes.
+	PositionEncodingUTF8 PositionEncodingKind = "utf-8"
+	// PositionEncodingUTF16 counts characters in UTF-16 code units. It is
+	// the default encoding every client supports.
+	PositionEncodingUTF16 PositionEncodingKind = "utf-16"
+	// PositionEncodingUTF32 counts characters in UTF-32 code units, i.e.
+	// unicode code points.
+	PositionEncodingUTF32 PositionEncodingKind = "utf-32"
+)
+
+// NegotiatePositionEncoding returns the first of the encodings preferred
+// by the server which the client supports.
+//
+// UTF-16 is returned if the client supports none of them.
+func NegotiatePositionEncoding(
+	caps ClientCapabilities,
+	preferred ...PositionEncodingKind,
+) PositionEncodingKind {
+	for _, enc := range preferred {
+		for _, supported := range caps.General.PositionEncodings {
+			if enc == supported {
+				return enc
+			}
+		}
+	}
+	return PositionEncodingUTF16
+}
+
+// EncodedLen returns the length of s in the code units of the encoding.
+//
+// The empty encoding is UTF-16.
+func EncodedLen(s string, enc PositionEncodingKind) int {
+	switch enc {
+	case PositionEncodingUTF8:
+		return len(s)
+	case PositionEncodingUTF32:
+		return utf8.RuneCountInString(s)
+	}
+	n := 0
+	for _, r := range s {
+		n += runeLen(r, enc)
+	}
+	return n
+}
+
+// OffsetAt returns the byte offset of the position in text.
+//
+// A character beyond the end of its line is clamped to the end of the line
+// as the specification requires. An error is returned if the position is
+// beyond the last line of the text or if it points into the middle of a
+// character.
+func OffsetAt(text string, pos Position, enc PositionEncodingKind) (int, error) {
+	if pos.Line < 0 || pos.Character < 0 {
+		return 0, fmt.Errorf("invalid position %s", pos)
+	}
+	start := 0
+	for line := 0; line < pos.Line; line++ {
+		end, next := lineEnd(text, start)
+		if next == end {
+			return 0, fmt.Errorf("position %s is beyond the last line", pos)
+		}
+		start = next
+	}
+	end, _ := lineEnd(text, start)
+	offset, units := start, 0
+	for units < pos.Character {
+		if offset >= end {
+			return end, nil
+		}
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		if enc == PositionEncodingUTF8 {
+			units += size
+		} else {
+			units += runeLen(r, enc)
+		}
+		offset += size
+	}
+	if units != pos.Character {
+		return 0, fmt.Errorf("position %s is inside a character", pos)
+	}
+	return offset, nil
+}
+
+// PositionAt returns the position of the byte offset in text.
+//
+// Offsets beyond the end of text are clamped to the end and offsets inside
+// a character are moved to its start.
+func PositionAt(text string, offset int, enc PositionEncodingKind) Position {
+	offset = min(max(offset, 0), len(text))
+	for offset > 0 && offset < len(text) && !utf8.RuneStart(text[offset]) {
+		offset--
+	}
+	pos, start := Position{}, 0
+	for {
+		end, next := lineEnd(text, start)
+		if offset <= end || next == end || offset < next {
+			pos.Character = EncodedLen(text[start:min(offset, end)], enc)
+			return pos
+		}
+		pos.Line++
+		start = next
+	}
+}
+
+// LineIndex converts byte offsets of a text into positions without
+// rescanning the text for every offset.
+type LineIndex struct {
+	text   string
+	enc    PositionEncodingKind
+	starts []int
+	ends   []int
+}
+
+// NewLineIndex indexes the lines of text for positions in the encoding.
+func NewLineIndex(text string, enc PositionEncodingKind) *LineIndex {
+	x := &LineIndex{text: text, enc: enc}
+	start := 0
+	for {
+		end, next := lineEnd(text, start)
+		x.starts = append(x.starts, start)
+		x.ends = append(x.ends, end)
+		if next == end {
+			return x
+		}
+		start = next
+	}
+}
+
+// PositionAt returns the position of the byte offset like PositionAt.
+func (x *LineIndex) PositionAt(offset int) Position {
+	offset = min(max(offset, 0), len(x.text))
+	for offset > 0 && offset < len(x.text) && !utf8.RuneStart(x.text[offset]) {
+		offset--
+	}
+	line := sort.SearchInts(x.starts, offset+1) - 1
+	return Position{
+		Line:      line,
+		Character: EncodedLen(x.text[x.starts[line]:min(offset, x.ends[line])], x.enc),
+	}
+}
+
+// LineLen returns the length of the line without its line terminator in
+// the code units of the encoding. Lines beyond the last line are empty.
+func (x *LineIndex) LineLen(line int) int {
+	if line < 0 || line >= len(x.starts) {
+		return 0
+	}
+	return EncodedLen(x.text[x.starts[line]:x.ends[line]], x.enc)
+}
+
+// Range returns the range between the byte offsets start and end.
+func (x *LineIndex) Range(start, end int) Range {
+	return Range{Start: x.PositionAt(start), End: x.PositionAt(end)}
+}
+
+// lineEnd returns the offset of the line terminator of the line starting
+// at start and the offset of the next line.
+//
+// Lines are terminated by `\n`, `\r\n` or `\r`. end equals next for the
+// last line.
+func lineEnd(text string, start int) (end, next int) {
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			return i, i + 1
+		case '\r':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				return i, i + 2
+			}
+			return i, i + 1
+		}
+	}
+	return len(text), len(text)
+}
+
+// runeLen returns the number of code units of r in the encoding.
+func runeLen(r rune, enc PositionEncodingKind) int {
+	switch enc {
+	case PositionEncodingUTF8:
+		return utf8.RuneLen(r)
+	case PositionEncodingUTF32:
+		return 1
+	}
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
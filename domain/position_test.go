@@ -0,0 +1,37 @@
+package domain
+
+import "testing"
+
+func TestOffsetAt(t *testing.T) {
+	const text = "aé😀\r\nb\n"
+	tests := []struct {
+		name    string
+		pos     Position
+		enc     PositionEncodingKind
+		want    int
+		wantErr bool
+	}{
+		{"start", Position{Line: 0, Character: 0}, PositionEncodingUTF16, 0, false},
+		{"after two byte rune", Position{Line: 0, Character: 2}, PositionEncodingUTF16, 3, false},
+		{"after surrogate pair", Position{Line: 0, Character: 4}, PositionEncodingUTF16, 7, false},
+		{"utf-8 units", Position{Line: 0, Character: 3}, PositionEncodingUTF8, 3, false},
+		{"utf-32 units", Position{Line: 0, Character: 3}, PositionEncodingUTF32, 7, false},
+		{"clamped to line end", Position{Line: 0, Character: 99}, PositionEncodingUTF16, 7, false},
+		{"clamped before newline", Position{Line: 1, Character: 5}, PositionEncodingUTF16, 10, false},
+		{"last empty line", Position{Line: 2, Character: 0}, PositionEncodingUTF16, 11, false},
+		{"beyond last line", Position{Line: 3, Character: 0}, PositionEncodingUTF16, 0, true},
+		{"inside surrogate pair", Position{Line: 0, Character: 3}, PositionEncodingUTF16, 0, true},
+		{"negative", Position{Line: 0, Character: -1}, PositionEncodingUTF16, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OffsetAt(text, tt.pos, tt.enc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("offset = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+// ReferencesRequest is a request to resolve project-wide references for the
+// symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_references
+type ReferencesRequest struct {
+	// ReferencesRequest embeds the Request struct
+	Request
+	// Params are the parameters for the references request.
+	Params ReferenceParams `json:"params"`
+}
+
+// ReferenceParams are the parameters of a references request.
+type ReferenceParams struct {
+	// ReferenceParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+	// Context is the context of the references request.
+	Context ReferenceContext `json:"context"`
+}
+
+// ReferenceContext is the context of a references request.
+type ReferenceContext struct {
+	// IncludeDeclaration includes the declaration of the current symbol.
+	IncludeDeclaration bool `json:"includeDeclaration"`
+}
+
+// ReferencesResponse is the response for a references request.
+type ReferencesResponse struct {
+	// ReferencesResponse embeds the Response struct
+	Response
+	// Result are the locations of the references.
+	Result []Location `json:"result"`
+}
+
+// Method returns the method for the references response
+func (r ReferencesResponse) Method() string {
+	return string(MethodTextDocumentReferences)
+}
+
+// DocumentHighlightRequest is a request to resolve the highlights of the
+// symbol at a given text document position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_documentHighlight
+type DocumentHighlightRequest struct {
+	// DocumentHighlightRequest embeds the Request struct
+	Request
+	// Params are the parameters for the document highlight request.
+	Params DocumentHighlightParams `json:"params"`
+}
+
+// DocumentHighlightParams are the parameters of a document highlight
+// request.
+type DocumentHighlightParams struct {
+	// DocumentHighlightParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+}
+
+// DocumentHighlightResponse is the response for a document highlight
+// request.
+type DocumentHighlightResponse struct {
+	// DocumentHighlightResponse embeds the Response struct
+	Response
+	// Result are the highlights in the document.
+	Result []DocumentHighlight `json:"result"`
+}
+
+// Method returns the method for the document highlight response
+func (r DocumentHighlightResponse) Method() string {
+	return string(MethodRequestTextDocumentDocumentHighlight)
+}
+
+// DocumentHighlight is a range inside a text document which deserves
+// special attention, usually because it is an occurrence of the symbol at
+// the cursor.
+type DocumentHighlight struct {
+	// Range is the range this highlight applies to.
+	Range Range `json:"range"`
+	// Kind is the highlight kind, the default is Text.
+	Kind DocumentHighlightKind `json:"kind,omitempty"`
+}
+
+// ReferenceClientCapabilities are the client capabilities for references.
+type ReferenceClientCapabilities struct {
+	// DynamicRegistration is whether references supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
+
+// DocumentHighlightClientCapabilities are the client capabilities for
+// document highlights.
+type DocumentHighlightClientCapabilities struct {
+	// DynamicRegistration is whether document highlight supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
@@ -0,0 +1,113 @@
+package domain
+
+// MethodTextDocumentPrepareRename is the prepare rename request method
+// used to test the validity of a rename at a position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_prepareRename
+const MethodTextDocumentPrepareRename Method = "textDocument/prepareRename"
+
+// RenameRequest is a request to rename the symbol at a position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_rename
+type RenameRequest struct {
+	// RenameRequest embeds the Request struct
+	Request
+	// Params are the parameters for the rename request.
+	Params RenameParams `json:"params"`
+}
+
+// RenameParams are the parameters of a rename request.
+type RenameParams struct {
+	// TextDocumentPositionParams is the position of the symbol to rename.
+	TextDocumentPositionParams
+	// NewName is the new name of the symbol.
+	NewName string `json:"newName"`
+}
+
+// RenameResponse is the response for a rename request.
+type RenameResponse struct {
+	// RenameResponse embeds the Response struct
+	Response
+	// Result is the edit renaming the symbol or nil if no rename is
+	// possible at the position.
+	Result *WorkspaceEdit `json:"result"`
+}
+
+// Method returns the method for the rename response
+func (r RenameResponse) Method() string {
+	return string(MethodTextDocumentRename)
+}
+
+// PrepareRenameRequest is a request to test the validity of a rename at a
+// position before the user picks the new name.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_prepareRename
+type PrepareRenameRequest struct {
+	// PrepareRenameRequest embeds the Request struct
+	Request
+	// Params are the parameters for the prepare rename request.
+	Params PrepareRenameParams `json:"params"`
+}
+
+// PrepareRenameParams are the parameters of a prepare rename request.
+type PrepareRenameParams struct {
+	// TextDocumentPositionParams is the position of the symbol to rename.
+	TextDocumentPositionParams
+}
+
+// PrepareRenameResponse is the response for a prepare rename request.
+type PrepareRenameResponse struct {
+	// PrepareRenameResponse embeds the Response struct
+	Response
+	// Result is either a *Range, a *PrepareRenameResult, a
+	// PrepareRenameDefaultBehavior or nil if a rename is not valid at the
+	// position.
+	Result interface{} `json:"result"`
+}
+
+// Method returns the method for the prepare rename response
+func (r PrepareRenameResponse) Method() string {
+	return string(MethodTextDocumentPrepareRename)
+}
+
+// PrepareRenameResult is the range of the symbol to rename with the text
+// the client should offer as the new name.
+type PrepareRenameResult struct {
+	// Range is the range of the symbol to rename.
+	Range Range `json:"range"`
+	// Placeholder is the initial new name.
+	Placeholder string `json:"placeholder"`
+}
+
+// PrepareRenameDefaultBehavior asks the client to select the symbol to
+// rename using its default behavior.
+type PrepareRenameDefaultBehavior struct {
+	// DefaultBehavior must be true.
+	DefaultBehavior bool `json:"defaultBehavior"`
+}
+
+// RenameOptions are the server capabilities for renames.
+type RenameOptions struct {
+	// PrepareProvider is whether the server supports prepare rename
+	// requests.
+	PrepareProvider bool `json:"prepareProvider,omitempty"`
+}
+
+// RenameClientCapabilities are the client capabilities for renames.
+type RenameClientCapabilities struct {
+	// DynamicRegistration is whether rename supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// PrepareSupport is whether the client supports testing the validity
+	// of a rename before it is executed.
+	PrepareSupport bool `json:"prepareSupport,omitempty"`
+	// PrepareSupportDefaultBehavior is the default behavior of the client
+	// for a PrepareRenameDefaultBehavior result.
+	PrepareSupportDefaultBehavior PrepareSupportDefaultBehavior `json:"prepareSupportDefaultBehavior,omitempty"`
+	// HonorsChangeAnnotations is whether the client honors the change
+	// annotations in the workspace edit of a rename.
+	HonorsChangeAnnotations bool `json:"honorsChangeAnnotations,omitempty"`
+}
@@ -0,0 +1,368 @@
+package domain
+
+import (
+	"encoding/json"
+)
+
+// Semantic Tokens Methods
+const (
+	// MethodTextDocumentSemanticTokensFull is the request method for the
+	// semantic tokens of a whole document.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokens_fullRequest
+	MethodTextDocumentSemanticTokensFull Method = "textDocument/semanticTokens/full"
+
+	// MethodTextDocumentSemanticTokensFullDelta is the request method for
+	// the changes of the semantic tokens of a whole document since a
+	// previous result.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokens_deltaRequest
+	MethodTextDocumentSemanticTokensFullDelta Method = "textDocument/semanticTokens/full/delta"
+
+	// MethodTextDocumentSemanticTokensRange is the request method for the
+	// semantic tokens of a range of a document.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokens_rangeRequest
+	MethodTextDocumentSemanticTokensRange Method = "textDocument/semanticTokens/range"
+
+	// MethodWorkspaceSemanticTokensRefresh is the request sent from the
+	// server to the client asking it to refresh all semantic tokens.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokens_refreshRequest
+	MethodWorkspaceSemanticTokensRefresh Method = "workspace/semanticTokens/refresh"
+)
+
+// Standard semantic token types.
+const (
+	// SemanticTokenNamespace is the `namespace` semantic token type.
+	SemanticTokenNamespace = "namespace"
+	// SemanticTokenType is the `type` semantic token type.
+	SemanticTokenType = "type"
+	// SemanticTokenClass is the `class` semantic token type.
+	SemanticTokenClass = "class"
+	// SemanticTokenEnum is the `enum` semantic token type.
+	SemanticTokenEnum = "enum"
+	// SemanticTokenInterface is the `interface` semantic token type.
+	SemanticTokenInterface = "interface"
+	// SemanticTokenStruct is the `struct` semantic token type.
+	SemanticTokenStruct = "struct"
+	// SemanticTokenTypeParameter is the `typeParameter` semantic token type.
+	SemanticTokenTypeParameter = "typeParameter"
+	// SemanticTokenParameter is the `parameter` semantic token type.
+	SemanticTokenParameter = "parameter"
+	// SemanticTokenVariable is the `variable` semantic token type.
+	SemanticTokenVariable = "variable"
+	// SemanticTokenProperty is the `property` semantic token type.
+	SemanticTokenProperty = "property"
+	// SemanticTokenEnumMember is the `enumMember` semantic token type.
+	SemanticTokenEnumMember = "enumMember"
+	// SemanticTokenEvent is the `event` semantic token type.
+	SemanticTokenEvent = "event"
+	// SemanticTokenFunction is the `function` semantic token type.
+	SemanticTokenFunction = "function"
+	// SemanticTokenMethod is the `method` semantic token type.
+	SemanticTokenMethod = "method"
+	// SemanticTokenMacro is the `macro` semantic token type.
+	SemanticTokenMacro = "macro"
+	// SemanticTokenKeyword is the `keyword` semantic token type.
+	SemanticTokenKeyword = "keyword"
+	// SemanticTokenModifier is the `modifier` semantic token modifier.
+	SemanticTokenModifier = "modifier"
+	// SemanticTokenComment is the `comment` semantic token type.
+	SemanticTokenComment = "comment"
+	// SemanticTokenString is the `string` semantic token type.
+	SemanticTokenString = "string"
+	// SemanticTokenNumber is the `number` semantic token type.
+	SemanticTokenNumber = "number"
+	// SemanticTokenRegexp is the `regexp` semantic token type.
+	SemanticTokenRegexp = "regexp"
+	// SemanticTokenOperator is the `operator` semantic token type.
+	SemanticTokenOperator = "operator"
+	// SemanticTokenDecorator is the `decorator` semantic token type.
+	SemanticTokenDecorator = "decorator"
+)
+
+// Standard semantic token modifiers.
+const (
+	// SemanticTokenModifierDeclaration is the `declaration` semantic token modifier.
+	SemanticTokenModifierDeclaration = "declaration"
+	// SemanticTokenModifierDefinition is the `definition` semantic token modifier.
+	SemanticTokenModifierDefinition = "definition"
+	// SemanticTokenModifierReadonly is the `readonly` semantic token modifier.
+	SemanticTokenModifierReadonly = "readonly"
+	// SemanticTokenModifierStatic is the `static` semantic token modifier.
+	SemanticTokenModifierStatic = "static"
+	// SemanticTokenModifierDeprecated is the `deprecated` semantic token modifier.
+	SemanticTokenModifierDeprecated = "deprecated"
+	// SemanticTokenModifierAbstract is the `abstract` semantic token modifier.
+	SemanticTokenModifierAbstract = "abstract"
+	// SemanticTokenModifierAsync is the `async` semantic token modifier.
+	SemanticTokenModifierAsync = "async"
+	// SemanticTokenModifierModification is the `modification` semantic token modifier.
+	SemanticTokenModifierModification = "modification"
+	// SemanticTokenModifierDocumentation is the `documentation` semantic token modifier.
+	SemanticTokenModifierDocumentation = "documentation"
+	// SemanticTokenModifierDefaultLibrary is the `defaultLibrary` semantic token modifier.
+	SemanticTokenModifierDefaultLibrary = "defaultLibrary"
+)
+
+// SemanticTokensLegend maps the integers of the encoded semantic tokens to
+// the names of token types and modifiers.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokensLegend
+type SemanticTokensLegend struct {
+	// TokenTypes are the token types indexed by the type of a token.
+	TokenTypes []string `json:"tokenTypes"`
+	// TokenModifiers are the token modifiers indexed by the bits of the
+	// modifiers of a token.
+	TokenModifiers []string `json:"tokenModifiers"`
+}
+
+// SemanticTokensParams are the parameters of a full semantic tokens
+// request.
+type SemanticTokensParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// SemanticTokensDeltaParams are the parameters of a full delta semantic
+// tokens request.
+type SemanticTokensDeltaParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// PreviousResultID is the result id of a previous response.
+	PreviousResultID string `json:"previousResultId"`
+}
+
+// SemanticTokensRangeParams are the parameters of a range semantic tokens
+// request.
+type SemanticTokensRangeParams struct {
+	// TextDocument is the text document.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	// Range is the range the semantic tokens are requested for.
+	Range Range `json:"range"`
+}
+
+// SemanticTokens are the encoded semantic tokens of a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#semanticTokens
+type SemanticTokens struct {
+	// ResultID identifies the tokens for a later delta request.
+	ResultID string `json:"resultId,omitempty"`
+	// Data are the tokens encoded as groups of five integers relative to
+	// the previous token.
+	Data []uint32 `json:"data"`
+}
+
+// SemanticTokensDelta are the changes of the encoded semantic tokens
+// since a previous result.
+type SemanticTokensDelta struct {
+	// ResultID identifies the tokens for a later delta request.
+	ResultID string `json:"resultId,omitempty"`
+	// Edits are the edits to transform the previous data into the new
+	// data.
+	Edits []SemanticTokensEdit `json:"edits"`
+}
+
+// SemanticTokensEdit replaces a part of the encoded semantic tokens.
+type SemanticTokensEdit struct {
+	// Start is the start offset of the edit in the data.
+	Start uint32 `json:"start"`
+	// DeleteCount is the number of elements to remove.
+	DeleteCount uint32 `json:"deleteCount"`
+	// Data are the elements to insert.
+	Data []uint32 `json:"data,omitempty"`
+}
+
+// SemanticTokensFullRequest is a request for the semantic tokens of a
+// whole document.
+type SemanticTokensFullRequest struct {
+	// SemanticTokensFullRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params SemanticTokensParams `json:"params"`
+}
+
+// SemanticTokensFullResponse is the response for a full semantic tokens
+// request.
+type SemanticTokensFullResponse struct {
+	// SemanticTokensFullResponse embeds the Response struct
+	Response
+	// Result are the semantic tokens of the document.
+	Result *SemanticTokens `json:"result"`
+}
+
+// Method returns the method for the full semantic tokens response
+func (r SemanticTokensFullResponse) Method() string {
+	return string(MethodTextDocumentSemanticTokensFull)
+}
+
+// SemanticTokensDeltaRequest is a request for the changes of the semantic
+// tokens of a whole document.
+type SemanticTokensDeltaRequest struct {
+	// SemanticTokensDeltaRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params SemanticTokensDeltaParams `json:"params"`
+}
+
+// SemanticTokensDeltaResponse is the response for a full delta semantic
+// tokens request.
+type SemanticTokensDeltaResponse struct {
+	// SemanticTokensDeltaResponse embeds the Response struct
+	Response
+	// Result is either a SemanticTokens or a SemanticTokensDelta.
+	Result interface{} `json:"result"`
+}
+
+// Method returns the method for the full delta semantic tokens response
+func (r SemanticTokensDeltaResponse) Method() string {
+	return string(MethodTextDocumentSemanticTokensFullDelta)
+}
+
+// SemanticTokensRangeRequest is a request for the semantic tokens of a
+// range of a document.
+type SemanticTokensRangeRequest struct {
+	// SemanticTokensRangeRequest embeds the Request struct
+	Request
+	// Params are the parameters for the request.
+	Params SemanticTokensRangeParams `json:"params"`
+}
+
+// SemanticTokensRangeResponse is the response for a range semantic tokens
+// request.
+type SemanticTokensRangeResponse struct {
+	// SemanticTokensRangeResponse embeds the Response struct
+	Response
+	// Result are the semantic tokens of the range.
+	Result *SemanticTokens `json:"result"`
+}
+
+// Method returns the method for the range semantic tokens response
+func (r SemanticTokensRangeResponse) Method() string {
+	return string(MethodTextDocumentSemanticTokensRange)
+}
+
+// SemanticTokensOptions are the server capabilities for semantic tokens.
+type SemanticTokensOptions struct {
+	// Legend is the legend used by the server.
+	Legend SemanticTokensLegend `json:"legend"`
+	// Range is whether the server supports range requests.
+	Range bool `json:"range,omitempty"`
+	// Full is set if the server supports full requests.
+	Full *SemanticTokensFullOptions `json:"full,omitempty"`
+}
+
+// SemanticTokensFullOptions are the server capabilities for full semantic
+// tokens requests.
+type SemanticTokensFullOptions struct {
+	// Delta is whether the server supports full delta requests.
+	Delta bool `json:"delta,omitempty"`
+}
+
+// SemanticTokensClientCapabilities are the client capabilities for
+// semantic tokens.
+type SemanticTokensClientCapabilities struct {
+	// DynamicRegistration is whether semantic tokens support dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// Requests are the requests the client sends.
+	Requests SemanticTokensClientRequests `json:"requests"`
+	// TokenTypes are the token types supported by the client.
+	TokenTypes []string `json:"tokenTypes"`
+	// TokenModifiers are the token modifiers supported by the client.
+	TokenModifiers []string `json:"tokenModifiers"`
+	// Formats are the token formats supported by the client. Only
+	// `relative` is defined.
+	Formats []string `json:"formats"`
+	// OverlappingTokenSupport is whether the client supports tokens that
+	// overlap each other.
+	OverlappingTokenSupport bool `json:"overlappingTokenSupport,omitempty"`
+	// MultilineTokenSupport is whether the client supports tokens that
+	// span multiple lines.
+	MultilineTokenSupport bool `json:"multilineTokenSupport,omitempty"`
+	// ServerCancelSupport is whether the client allows the server to
+	// cancel a semantic token request with ServerCancelled.
+	ServerCancelSupport bool `json:"serverCancelSupport,omitempty"`
+	// AugmentsSyntaxTokens is whether the client merges the semantic
+	// tokens with its own syntax highlighting.
+	AugmentsSyntaxTokens bool `json:"augmentsSyntaxTokens,omitempty"`
+}
+
+// SemanticTokensClientRequests are the semantic token requests a client
+// sends.
+type SemanticTokensClientRequests struct {
+	// Range is whether the client sends range requests.
+	Range bool
+	// Full is whether the client sends full requests.
+	Full bool
+	// Delta is whether the client sends full delta requests.
+	Delta bool
+}
+
+// semanticTokensClientRequests is the wire format of the semantic token
+// requests of a client where each request is either a boolean or an
+// object.
+type semanticTokensClientRequests struct {
+	Range json.RawMessage `json:"range,omitempty"`
+	Full  json.RawMessage `json:"full,omitempty"`
+}
+
+// MarshalJSON encodes the requests in the wire format.
+func (r SemanticTokensClientRequests) MarshalJSON() ([]byte, error) {
+	var wire semanticTokensClientRequests
+	if r.Range {
+		wire.Range = json.RawMessage(`true`)
+	}
+	if r.Delta {
+		wire.Full = json.RawMessage(`{"delta":true}`)
+	} else if r.Full {
+		wire.Full = json.RawMessage(`true`)
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON decodes the requests from the wire format.
+func (r *SemanticTokensClientRequests) UnmarshalJSON(data []byte) error {
+	var wire semanticTokensClientRequests
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*r = SemanticTokensClientRequests{}
+	var err error
+	if r.Range, _, err = boolOrObject(wire.Range); err != nil {
+		return err
+	}
+	r.Full, r.Delta, err = boolOrObject(wire.Full)
+	return err
+}
+
+// boolOrObject decodes a request capability which is either a boolean or
+// an object with an optional delta property.
+func boolOrObject(data json.RawMessage) (supported, delta bool, err error) {
+	if len(data) == 0 || string(data) == "null" {
+		return false, false, nil
+	}
+	if err := json.Unmarshal(data, &supported); err == nil {
+		return supported, false, nil
+	}
+	var options SemanticTokensFullOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return false, false, err
+	}
+	return true, options.Delta, nil
+}
+
+// SemanticTokensWorkspaceClientCapabilities are the client capabilities
+// for semantic tokens in the workspace.
+type SemanticTokensWorkspaceClientCapabilities struct {
+	// RefreshSupport is whether the client supports the
+	// workspace/semanticTokens/refresh request.
+	RefreshSupport bool `json:"refreshSupport,omitempty"`
+}
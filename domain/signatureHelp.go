@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SignatureHelpRequest is a request for signature information at a cursor
+// position.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_signatureHelp
+type SignatureHelpRequest struct {
+	// SignatureHelpRequest embeds the Request struct
+	Request
+	// Params are the parameters for the signature help request.
+	Params SignatureHelpParams `json:"params"`
+}
+
+// SignatureHelpParams are the parameters of a signature help request.
+type SignatureHelpParams struct {
+	// SignatureHelpParams embeds the TextDocumentPositionParams struct
+	TextDocumentPositionParams
+	// Context is the signature help context. It is only available if the
+	// client specifies to send it.
+	Context *SignatureHelpContext `json:"context,omitempty"`
+}
+
+// SignatureHelpContext contains additional information about the context
+// in which a signature help request was triggered.
+type SignatureHelpContext struct {
+	// TriggerKind is the action that caused signature help to be
+	// triggered.
+	TriggerKind SignatureHelpTriggerKind `json:"triggerKind"`
+	// TriggerCharacter is the character that caused signature help to be
+	// triggered if the trigger kind is TriggerCharacter.
+	TriggerCharacter string `json:"triggerCharacter,omitempty"`
+	// IsRetrigger is true if signature help was already showing when it
+	// was triggered.
+	IsRetrigger bool `json:"isRetrigger"`
+	// ActiveSignatureHelp is the currently active signature help with its
+	// active signature updated by the user.
+	ActiveSignatureHelp *SignatureHelp `json:"activeSignatureHelp,omitempty"`
+}
+
+// SignatureHelpResponse is the response for a signature help request.
+type SignatureHelpResponse struct {
+	// SignatureHelpResponse embeds the Response struct
+	Response
+	// Result is the signature help or nil if there is none.
+	Result *SignatureHelp `json:"result"`
+}
+
+// Method returns the method for the signature help response
+func (r SignatureHelpResponse) Method() string {
+	return string(MethodRequestTextDocumentSignatureHelp)
+}
+
+// SignatureHelp represents the signature of something callable.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#signatureHelp
+type SignatureHelp struct {
+	// Signatures are one or more signatures.
+	Signatures []SignatureInformation `json:"signatures"`
+	// ActiveSignature is the index of the active signature.
+	ActiveSignature int `json:"activeSignature,omitempty"`
+	// ActiveParameter is the index of the active parameter of the active
+	// signature.
+	ActiveParameter int `json:"activeParameter,omitempty"`
+}
+
+// SignatureInformation represents the signature of something callable.
+type SignatureInformation struct {
+	// Label is the label of this signature shown in the UI.
+	Label string `json:"label"`
+	// Documentation is the human-readable doc-comment of this signature.
+	Documentation *MarkupContent `json:"documentation,omitempty"`
+	// Parameters are the parameters of this signature.
+	Parameters []ParameterInformation `json:"parameters,omitempty"`
+	// ActiveParameter is the index of the active parameter overriding the
+	// active parameter of the signature help.
+	ActiveParameter *int `json:"activeParameter,omitempty"`
+}
+
+// NewSignatureInformation creates the signature information with the given
+// label and parameters.
+//
+// The parameters are located in the label in order and labelled with
+// their offsets in it if the client supports label offsets. Other
+// parameters are labelled with their text.
+func NewSignatureInformation(
+	caps SignatureHelpClientCapabilities,
+	label string,
+	params ...string,
+) SignatureInformation {
+	offsets := caps.SignatureInformation.ParameterInformation.LabelOffsetSupport
+	info := SignatureInformation{
+		Label:      label,
+		Parameters: make([]ParameterInformation, len(params)),
+	}
+	searched := 0
+	for i, param := range params {
+		index := strings.Index(label[searched:], param)
+		if !offsets || param == "" || index < 0 {
+			info.Parameters[i].Label = ParameterLabel{Text: param}
+			continue
+		}
+		start := searched + index
+		searched = start + len(param)
+		info.Parameters[i].Label = ParameterLabel{Offsets: &[2]int{
+			EncodedLen(label[:start], PositionEncodingUTF16),
+			EncodedLen(label[:searched], PositionEncodingUTF16),
+		}}
+	}
+	return info
+}
+
+// ParameterInformation represents a parameter of a callable signature.
+type ParameterInformation struct {
+	// Label is the label of this parameter.
+	Label ParameterLabel `json:"label"`
+	// Documentation is the human-readable doc-comment of this parameter.
+	Documentation *MarkupContent `json:"documentation,omitempty"`
+}
+
+// ParameterLabel is the label of a parameter which is either a substring
+// of the label of its signature or the UTF-16 offsets of that substring.
+type ParameterLabel struct {
+	// Text is the text of the label.
+	Text string
+	// Offsets are the inclusive start and exclusive end offsets of the
+	// label in the signature label. Text is ignored if they are set.
+	Offsets *[2]int
+}
+
+// MarshalJSON marshals the label as its offsets or its text.
+func (l ParameterLabel) MarshalJSON() ([]byte, error) {
+	if l.Offsets != nil {
+		return json.Marshal(l.Offsets)
+	}
+	return json.Marshal(l.Text)
+}
+
+// UnmarshalJSON unmarshals the label from offsets or a text.
+func (l *ParameterLabel) UnmarshalJSON(data []byte) error {
+	var offsets [2]int
+	if err := json.Unmarshal(data, &offsets); err == nil {
+		*l = ParameterLabel{Offsets: &offsets}
+		return nil
+	}
+	*l = ParameterLabel{}
+	return json.Unmarshal(data, &l.Text)
+}
+
+// SignatureHelpOptions are the server capabilities for signature help.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#signatureHelpOptions
+type SignatureHelpOptions struct {
+	// TriggerCharacters are the characters that trigger signature help
+	// automatically.
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+	// RetriggerCharacters are the characters that re-trigger signature
+	// help while it is showing.
+	RetriggerCharacters []string `json:"retriggerCharacters,omitempty"`
+}
+
+// SignatureHelpClientCapabilities are the client capabilities for
+// signature help.
+type SignatureHelpClientCapabilities struct {
+	// DynamicRegistration is whether signature help supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// SignatureInformation are the client capabilities specific to
+	// signature information.
+	SignatureInformation struct {
+		// DocumentationFormat are the documentation formats supported by
+		// the client in the order of preference.
+		DocumentationFormat []MarkupKind `json:"documentationFormat,omitempty"`
+		// ParameterInformation are the client capabilities specific to
+		// parameter information.
+		ParameterInformation struct {
+			// LabelOffsetSupport is whether the client supports parameter
+			// labels given as offsets.
+			LabelOffsetSupport bool `json:"labelOffsetSupport,omitempty"`
+		} `json:"parameterInformation"`
+		// ActiveParameterSupport is whether the client supports the active
+		// parameter property of signature information.
+		ActiveParameterSupport bool `json:"activeParameterSupport,omitempty"`
+	} `json:"signatureInformation"`
+	// ContextSupport is whether the client sends additional context
+	// information for a signature help request.
+	ContextSupport bool `json:"contextSupport,omitempty"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewSignatureInformation(t *testing.T) {
+	var offsets SignatureHelpClientCapabilities
+	offsets.SignatureInformation.ParameterInformation.LabelOffsetSupport = true
+	tests := []struct {
+		name string
+		caps SignatureHelpClientCapabilities
+		want string
+	}{
+		{"offsets", offsets, `[{"label":[5,10]},{"label":[12,17]},{"label":"z int"}]`},
+		{"strings", SignatureHelpClientCapabilities{}, `[{"label":"a int"},{"label":"b int"},{"label":"z int"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := NewSignatureInformation(tt.caps, "f(é, a int, b int)", "a int", "b int", "z int")
+			data, err := json.Marshal(info.Parameters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("parameters = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package domain
+
+import "encoding/json"
+
+// Workspace Symbol Methods
+const (
+	// MethodWorkspaceSymbol is the workspace symbol request method used to
+	// search the symbols of the whole workspace.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_symbol
+	MethodWorkspaceSymbol Method = "workspace/symbol"
+
+	// MethodWorkspaceSymbolResolve is the workspace symbol resolve request
+	// method used to compute the range of a workspace symbol lazily.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_symbolResolve
+	MethodWorkspaceSymbolResolve Method = "workspaceSymbol/resolve"
+)
+
+// DocumentSymbolRequest is a request to list the symbols of a document.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_documentSymbol
+type DocumentSymbolRequest struct {
+	// DocumentSymbolRequest embeds the Request struct
+	Request
+	// Params are the parameters for the document symbol request.
+	Params DocumentSymbolParams `json:"params"`
+}
+
+// DocumentSymbolParams are the parameters of a document symbol request.
+type DocumentSymbolParams struct {
+	// TextDocument is the text document to list the symbols of.
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+// DocumentSymbolResponse is the response for a document symbol request.
+type DocumentSymbolResponse struct {
+	// DocumentSymbolResponse embeds the Response struct
+	Response
+	// Result is either a []DocumentSymbol or a []SymbolInformation.
+	Result interface{} `json:"result"`
+}
+
+// Method returns the method for the document symbol response
+func (r DocumentSymbolResponse) Method() string {
+	return string(MethodRequestTextDocumentDocumentSymbol)
+}
+
+// DocumentSymbol represents programming constructs like variables, classes
+// and interfaces appearing in a document. Document symbols can be
+// hierarchical.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#documentSymbol
+type DocumentSymbol struct {
+	// Name is the name of this symbol.
+	Name string `json:"name"`
+	// Detail is more detail for this symbol, e.g. the signature of a
+	// function.
+	Detail string `json:"detail,omitempty"`
+	// Kind is the kind of this symbol.
+	Kind SymbolKind `json:"kind"`
+	// Tags are the tags of this symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+	// Range encloses this symbol including leading and trailing whitespace
+	// and comments.
+	Range Range `json:"range"`
+	// SelectionRange is the range that should be selected and revealed when
+	// this symbol is picked, e.g. the name of a function. It must be
+	// contained in the range.
+	SelectionRange Range `json:"selectionRange"`
+	// Children are the children of this symbol, e.g. the properties of a
+	// class.
+	Children []DocumentSymbol `json:"children,omitempty"`
+}
+
+// SymbolInformation represents information about programming constructs
+// like variables, classes and interfaces.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#symbolInformation
+type SymbolInformation struct {
+	// Name is the name of this symbol.
+	Name string `json:"name"`
+	// Kind is the kind of this symbol.
+	Kind SymbolKind `json:"kind"`
+	// Tags are the tags of this symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+	// Location is the location of this symbol.
+	Location Location `json:"location"`
+	// ContainerName is the name of the symbol containing this symbol.
+	ContainerName string `json:"containerName,omitempty"`
+}
+
+// FlattenDocumentSymbols flattens the hierarchical symbols of the document
+// at uri into symbol information in depth-first order.
+//
+// The container name of a symbol is the name of its parent.
+func FlattenDocumentSymbols(uri string, symbols []DocumentSymbol) []SymbolInformation {
+	flat := []SymbolInformation{}
+	var walk func(container string, symbols []DocumentSymbol)
+	walk = func(container string, symbols []DocumentSymbol) {
+		for _, symbol := range symbols {
+			flat = append(flat, SymbolInformation{
+				Name: symbol.Name,
+				Kind: symbol.Kind,
+				Tags: symbol.Tags,
+				Location: Location{
+					URI:   uri,
+					Range: symbol.Range,
+				},
+				ContainerName: container,
+			})
+			walk(symbol.Name, symbol.Children)
+		}
+	}
+	walk("", symbols)
+	return flat
+}
+
+// WorkspaceSymbolRequest is a request to search the symbols of the
+// workspace.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_symbol
+type WorkspaceSymbolRequest struct {
+	// WorkspaceSymbolRequest embeds the Request struct
+	Request
+	// Params are the parameters for the workspace symbol request.
+	Params WorkspaceSymbolParams `json:"params"`
+}
+
+// WorkspaceSymbolParams are the parameters of a workspace symbol request.
+type WorkspaceSymbolParams struct {
+	// Query is a query string to filter symbols by. The empty query
+	// requests all symbols.
+	Query string `json:"query"`
+}
+
+// WorkspaceSymbolResponse is the response for a workspace symbol request.
+type WorkspaceSymbolResponse struct {
+	// WorkspaceSymbolResponse embeds the Response struct
+	Response
+	// Result are the matching symbols.
+	Result []WorkspaceSymbol `json:"result"`
+}
+
+// Method returns the method for the workspace symbol response
+func (r WorkspaceSymbolResponse) Method() string {
+	return string(MethodWorkspaceSymbol)
+}
+
+// WorkspaceSymbol is a special workspace symbol that supports locations
+// without a range.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspaceSymbol
+type WorkspaceSymbol struct {
+	// Name is the name of this symbol.
+	Name string `json:"name"`
+	// Kind is the kind of this symbol.
+	Kind SymbolKind `json:"kind"`
+	// Tags are the tags of this symbol.
+	Tags []SymbolTag `json:"tags,omitempty"`
+	// ContainerName is the name of the symbol containing this symbol.
+	ContainerName string `json:"containerName,omitempty"`
+	// Location is the location of this symbol. Its range is resolved by a
+	// workspace symbol resolve request if it is missing.
+	Location WorkspaceSymbolLocation `json:"location"`
+	// Data is preserved between a workspace symbol request and a workspace
+	// symbol resolve request.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// WorkspaceSymbolLocation is the location of a workspace symbol with an
+// optional range.
+type WorkspaceSymbolLocation struct {
+	// URI is the uri of the document of the symbol.
+	URI string `json:"uri"`
+	// Range is the range of the symbol in the document.
+	Range *Range `json:"range,omitempty"`
+}
+
+// WorkspaceSymbolResolveRequest is a request to resolve the range of a
+// workspace symbol.
+type WorkspaceSymbolResolveRequest struct {
+	// WorkspaceSymbolResolveRequest embeds the Request struct
+	Request
+	// Params is the workspace symbol to resolve.
+	Params WorkspaceSymbol `json:"params"`
+}
+
+// WorkspaceSymbolResolveResponse is the response for a workspace symbol
+// resolve request.
+type WorkspaceSymbolResolveResponse struct {
+	// WorkspaceSymbolResolveResponse embeds the Response struct
+	Response
+	// Result is the resolved workspace symbol.
+	Result WorkspaceSymbol `json:"result"`
+}
+
+// Method returns the method for the workspace symbol resolve response
+func (r WorkspaceSymbolResolveResponse) Method() string {
+	return string(MethodWorkspaceSymbolResolve)
+}
+
+// WorkspaceSymbolOptions are the server capabilities for workspace
+// symbols.
+type WorkspaceSymbolOptions struct {
+	// ResolveProvider is whether the server provides support to resolve
+	// the range of a workspace symbol.
+	ResolveProvider bool `json:"resolveProvider,omitempty"`
+}
+
+// DocumentSymbolClientCapabilities are the client capabilities for
+// document symbols.
+type DocumentSymbolClientCapabilities struct {
+	// DynamicRegistration is whether document symbol supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// SymbolKind are the symbol kinds supported by the client.
+	SymbolKind SymbolKindCapabilities `json:"symbolKind"`
+	// HierarchicalDocumentSymbolSupport is whether the client supports
+	// hierarchical document symbols.
+	HierarchicalDocumentSymbolSupport bool `json:"hierarchicalDocumentSymbolSupport,omitempty"`
+	// TagSupport are the symbol tags supported by the client.
+	TagSupport *SymbolTagCapabilities `json:"tagSupport,omitempty"`
+	// LabelSupport is whether the client supports an additional label
+	// presented in the UI when registering a document symbol provider.
+	LabelSupport bool `json:"labelSupport,omitempty"`
+}
+
+// WorkspaceSymbolClientCapabilities are the client capabilities for
+// workspace symbols.
+type WorkspaceSymbolClientCapabilities struct {
+	// DynamicRegistration is whether workspace symbol supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// SymbolKind are the symbol kinds supported by the client.
+	SymbolKind SymbolKindCapabilities `json:"symbolKind"`
+	// TagSupport are the symbol tags supported by the client.
+	TagSupport *SymbolTagCapabilities `json:"tagSupport,omitempty"`
+	// ResolveSupport are the properties the client can resolve lazily,
+	// usually `location.range`.
+	ResolveSupport *ResolveSupport `json:"resolveSupport,omitempty"`
+}
+
+// SymbolKindCapabilities lists the symbol kinds supported by a client.
+type SymbolKindCapabilities struct {
+	// ValueSet are the symbol kinds supported by the client.
+	ValueSet []SymbolKind `json:"valueSet,omitempty"`
+}
+
+// SymbolTagCapabilities lists the symbol tags supported by a client.
+type SymbolTagCapabilities struct {
+	// ValueSet are the symbol tags supported by the client.
+	ValueSet []SymbolTag `json:"valueSet"`
+}
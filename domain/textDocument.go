@@ -74,6 +74,13 @@ const (
 	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_didClose
 	MethodTextDocumentDidClose Method = "textDocument/didClose"
 
+	// MethodTextDocumentDidChange is the text document did change
+	// notification method for the language server protocol.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_didChange
+	MethodTextDocumentDidChange Method = "textDocument/didChange"
+
 	// MethodTextDocumentRangeFormatting is the text document range formatting method for the LSP
 	//
 	// Microsoft LSP Docs:
@@ -120,6 +127,15 @@ type TextDocumentURI struct {
 	URL url.URL `json:"url"`
 }
 
+// TextDocumentIdentifier identifies a text document by its uri.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocumentIdentifier
+type TextDocumentIdentifier struct {
+	// URI is the uri of the text document.
+	URI string `json:"uri"`
+}
+
 // NotificationDidOpenTextDocument is a notification that is sent when
 // the client opens a text document.
 //
@@ -156,55 +172,6 @@ type DidSaveTextDocumentParams struct {
 	TextDocument TextDocumentURI `json:"textDocument"`
 }
 
-// CodeActionRequest is a request for a code action to the language server.
-//
-// Microsoft LSP Docs:
-// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_code
-type CodeActionRequest struct {
-	// CodeActionRequest embeds the Request struct
-	Request
-	// Params are the parameters for the code action request.
-	Params TextDocumentCodeActionParams `json:"params"`
-}
-
-// TextDocumentCodeActionParams are the parameters for a code action request.
-type TextDocumentCodeActionParams struct {
-	// TextDocument is the text document for the code action request.
-	TextDocument TextDocumentURI `json:"textDocument"`
-	// Range is the range for the code action request.
-	Range Range `json:"range"`
-	// Context is the context for the code action request.
-	Context CodeActionContext `json:"context"`
-}
-
-// TextDocumentCodeActionResponse is the response for a code action request.
-type TextDocumentCodeActionResponse struct {
-	// TextDocumentCodeActionResponse embeds the Response struct
-	Response
-	// Result is the result for the code action request.
-	Result []CodeAction `json:"result"`
-}
-
-// Method returns the method for the code action response
-func (r TextDocumentCodeActionResponse) Method() string {
-	return "textDocument/codeAction"
-}
-
-// CodeActionContext is the context for a code action request.
-type CodeActionContext struct {
-	// Add fields for CodeActionContext as needed
-}
-
-// CodeAction is a code action for a given text document.
-type CodeAction struct {
-	// Title is the title for the code action.
-	Title string `json:"title"`
-	// Edit is the edit for the code action.
-	Edit *WorkspaceEdit `json:"edit,omitempty"`
-	// Command is the command for the code action.
-	Command *Command `json:"command,omitempty"`
-}
-
 // Command is a command for a given text document.
 //
 // Microsoft LSP Docs:
@@ -218,54 +185,6 @@ type Command struct {
 	Arguments []interface{} `json:"arguments,omitempty"`
 }
 
-// CompletionRequest is a request for a completion to the language server
-//
-// Microsoft LSP Docs:
-// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_completion
-type CompletionRequest struct {
-	// CompletionRequest embeds the Request struct
-	Request
-	// Params are the parameters for the completion request
-	Params CompletionParams `json:"params"`
-}
-
-// CompletionParams is a struct for the completion params
-type CompletionParams struct {
-	// CompletionParams embeds the TextDocumentPositionParams struct
-	TextDocumentPositionParams
-}
-
-// CompletionResponse is a response for a completion to the language server
-//
-// Microsoft LSP Docs:
-// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_completion
-type CompletionResponse struct {
-	// CompletionResponse embeds the Response struct
-	Response
-	// Result is the result of the completion request
-	Result []CompletionItem `json:"result"`
-}
-
-// CompletionItem is a struct for a completion item
-//
-// Microsoft LSP Docs:
-// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocument_completion
-type CompletionItem struct {
-	// Label is the label for the completion item
-	Label string `json:"label"`
-	// Detail is the detail for the completion item
-	Detail string `json:"detail"`
-	// Documentation is the documentation for the completion item
-	Documentation string `json:"documentation"`
-	// Kind is the kind of the completion item
-	Kind CompletionItemKind `json:"kind"`
-}
-
-// CompletionItemTag is a struct for a completion item tag
-type CompletionItemTag struct {
-	Deprecated bool `json:"deprecated"`
-}
-
 // TextDocumentItem is a text document.
 type TextDocumentItem struct {
 	// URI is the uri for the text document.
@@ -292,7 +211,7 @@ type VersionTextDocumentIdentifier struct {
 // TextDocumentPositionParams is a text document position parameters.
 type TextDocumentPositionParams struct {
 	// TextDocument is the text document for the position parameters.
-	TextDocument TextDocumentURI `json:"textDocument"`
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
 	// Position is the position for the text document.
 	Position Position `json:"position"`
 }
@@ -310,6 +229,22 @@ func (p Position) String() string {
 	return fmt.Sprintf("Line: %d, Character: %d", p.Line, p.Character)
 }
 
+// Compare returns -1, 0 or 1 if the position is before, equal to or after
+// the other position.
+func (p Position) Compare(other Position) int {
+	switch {
+	case p.Line < other.Line:
+		return -1
+	case p.Line > other.Line:
+		return 1
+	case p.Character < other.Character:
+		return -1
+	case p.Character > other.Character:
+		return 1
+	}
+	return 0
+}
+
 // Location is a location inside a resource, such as a line
 // inside a text file.
 type Location struct {
@@ -327,10 +262,25 @@ type Range struct {
 	End Position `json:"end"`
 }
 
+// Contains reports whether the position is inside the range including its
+// start and end.
+func (r Range) Contains(pos Position) bool {
+	return r.Start.Compare(pos) <= 0 && pos.Compare(r.End) <= 0
+}
+
 // WorkspaceEdit is the workspace edit object.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspaceEdit
 type WorkspaceEdit struct {
 	// Changes is the changes for the workspace edit.
-	Changes map[string][]TextEdit `json:"changes"`
+	Changes map[string][]TextEdit `json:"changes,omitempty"`
+	// DocumentChanges are versioned text document edits and file
+	// operations applied in order. Clients supporting them ignore Changes.
+	DocumentChanges []DocumentChange `json:"documentChanges,omitempty"`
+	// ChangeAnnotations are the annotations referenced by the annotation
+	// ids of the document changes.
+	ChangeAnnotations map[string]ChangeAnnotation `json:"changeAnnotations,omitempty"`
 }
 
 // TextEdit represents an edit operation on a single text document.
@@ -376,7 +326,19 @@ func (r HoverResponse) Method() string {
 // language server.
 type HoverResult struct {
 	// Contents is the contents for the hover result.
-	Contents string `json:"contents"`
+	Contents MarkupContent `json:"contents"`
+	// Range is an optional range used to visualize the hover, e.g. by
+	// changing the background color.
+	Range *Range `json:"range,omitempty"`
+}
+
+// HoverClientCapabilities are the client capabilities for hovers.
+type HoverClientCapabilities struct {
+	// DynamicRegistration is whether hover supports dynamic registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+	// ContentFormat are the content formats supported by the client for
+	// the contents of a hover in the order of preference.
+	ContentFormat []MarkupKind `json:"contentFormat,omitempty"`
 }
 
 // TextDocumentDidChangeNotification is sent from the client to the server to signal
@@ -410,93 +372,6 @@ type TextDocumentContentChangeEvent struct {
 	Text string `json:"text"`
 }
 
-// CompletionItemKind is an enum for completion item kinds.
-type CompletionItemKind int
-
-const (
-	// Text is a completion item kind
-	Text CompletionItemKind = iota + 1
-	// CompletionItemKindMethod is a completion item kind for a method or function completion
-	CompletionItemKindMethod
-	// CompletionItemKindFunction is a completion item kind for a function completion
-	CompletionItemKindFunction
-	// CompletionItemKindConstructor is a completion item kind for a constructor completion
-	CompletionItemKindConstructor
-	// CompletionItemKindField is a completion item kind for a field completion
-	CompletionItemKindField
-	// CompletionItemKindVariable is a completion item kind for a variable completion
-	CompletionItemKindVariable
-	// CompletionItemKindClass is a completion item kind for a class completion
-	CompletionItemKindClass
-	// CompletionItemKindInterface is a completion item kind for an interface completion
-	CompletionItemKindInterface
-	// CompletionItemKindModule is a completion item kind for a module completion
-	CompletionItemKindModule
-	// CompletionItemKindProperty is a completion item kind for a property completion
-	CompletionItemKindProperty
-	// CompletionItemKindUnit is a completion item kind for a unit
-	CompletionItemKindUnit
-	// CompletionItemKindValue is a completion item kind for a value
-	CompletionItemKindValue
-	// CompletionItemKindEnum is a completion item kind for an enum
-	CompletionItemKindEnum
-	// CompletionItemKindKeyword is a completion item kind for a keyword
-	CompletionItemKindKeyword
-	// CompletionItemKindSnippet is a completion item kind for a snippet
-	CompletionItemKindSnippet
-	// CompletionItemKindColor is a completion item kind for a color
-	CompletionItemKindColor
-	// CompletionItemKindFile is a completion item kind for a file
-	CompletionItemKindFile
-	// CompletionItemKindReference is a completion item kind for a reference
-	CompletionItemKindReference
-	// CompletionItemKindFolder is a completion item kind for a folder
-	CompletionItemKindFolder
-	// CompletionItemKindEnumMember is a completion item kind for an enum member
-	CompletionItemKindEnumMember
-	// CompletionItemKindConstant is a completion item kind for a constant
-	CompletionItemKindConstant
-	// CompletionItemKindStruct is a completion item kind for a struct
-	CompletionItemKindStruct
-	// CompletionItemKindEvent is a completion item kind for an event
-	CompletionItemKindEvent
-	// CompletionItemKindOperator is a completion item kind for an operator
-	CompletionItemKindOperator
-	// CompletionItemKindTypeParameter is a completion item kind for a type parameter
-	CompletionItemKindTypeParameter
-)
-
-// String returns the string representation of the CompletionItemKind.
-func (c CompletionItemKind) String() string {
-	return [...]string{
-		"Text",
-		"Method",
-		"Function",
-		"Constructor",
-		"Field",
-		"Variable",
-		"Class",
-		"Interface",
-		"Module",
-		"Property",
-		"Unit",
-		"Value",
-		"Enum",
-		"Keyword",
-		"Snippet",
-		"Color",
-		"File",
-		"Reference",
-		"Folder",
-		"EnumMember",
-		"Constant",
-		"Struct",
-		"Event",
-		"Operator",
-		"TypeParameter",
-	}[c-1]
-}
-
 // DidCloseTextDocumentParamsNotification is a struct for the did close text document params notification
 //
 // Microsoft LSP Docs:
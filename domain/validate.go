@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Enum is implemented by the enum types of the protocol.
+type Enum interface {
+	fmt.Stringer
+	// IsValid reports whether the value is a known value of the enum.
+	IsValid() bool
+}
+
+var (
+	// enumType is the reflected type of the Enum interface.
+	enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+	// capabilitiesType is the reflected type of ClientCapabilities.
+	capabilitiesType = reflect.TypeOf(ClientCapabilities{})
+)
+
+// DecodeParams decodes the params of a request or notification into v and
+// validates all enum values contained in it.
+//
+// Decoding and validation errors are returned as *Error with the
+// CodeInvalidParams code so they can be sent to the client as is.
+func DecodeParams(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return Validate(v)
+}
+
+// Validate reports the first enum value in v which is not a known value of
+// its enum as *Error with the CodeInvalidParams code.
+//
+// Zero values are treated as unset optional fields and are not reported.
+// Client capabilities are not validated since the value sets of newer
+// clients contain values unknown to this package which servers must
+// tolerate.
+func Validate(v interface{}) error {
+	return validate(reflect.ValueOf(v), "params")
+}
+
+// validate walks the value v located at path looking for invalid enum
+// values.
+func validate(v reflect.Value, path string) error {
+	if !v.IsValid() || v.Type() == capabilitiesType {
+		return nil
+	}
+	if v.Type().Implements(enumType) && v.Kind() != reflect.Pointer &&
+		v.Kind() != reflect.Interface {
+		if v.IsZero() {
+			return nil
+		}
+		enum := v.Interface().(Enum)
+		if !enum.IsValid() {
+			return &Error{
+				Code:    CodeInvalidParams,
+				Message: fmt.Sprintf("%s: invalid %s", path, enum),
+			}
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return validate(v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := path
+			if !field.Anonymous {
+				fieldPath = path + "." + jsonName(field)
+			}
+			if err := validate(v.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := validate(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := validate(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonName returns the name of the field in its JSON encoding.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
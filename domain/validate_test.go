@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeParams(t *testing.T) {
+	type nested struct {
+		Kinds    []CompletionItemKind          `json:"kinds"`
+		ByName   map[string]DiagnosticSeverity `json:"byName"`
+		Symbol   *SymbolKind                   `json:"symbol"`
+		Embedded struct {
+			Sync TextDocumentSyncKind `json:"sync"`
+		} `json:"embedded"`
+	}
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", `{"kinds":[1,25],"byName":{"a":4},"symbol":26,"embedded":{"sync":2}}`, ""},
+		{"zero values are unset", `{"kinds":[0],"byName":{"a":0},"symbol":0}`, ""},
+		{"slice", `{"kinds":[1,26]}`, "params.kinds[1]: invalid CompletionItemKind(26)"},
+		{"map", `{"byName":{"a":5}}`, "params.byName[a]: invalid DiagnosticSeverity(5)"},
+		{"pointer", `{"symbol":27}`, "params.symbol: invalid SymbolKind(27)"},
+		{"nested struct", `{"embedded":{"sync":3}}`, "params.embedded.sync: invalid TextDocumentSyncKind(3)"},
+		{"undecodable", `{"kinds":"a"}`, "json: cannot unmarshal string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v nested
+			err := DecodeParams([]byte(tt.data), &v)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("err = %v, want nil", err)
+				}
+				return
+			}
+			var rpcErr *Error
+			if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+				t.Fatalf("err = %v, want invalid params", err)
+			}
+			if !strings.HasPrefix(rpcErr.Message, tt.wantErr) {
+				t.Errorf("message = %q, want prefix %q", rpcErr.Message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeParamsToleratesNewerCapabilities(t *testing.T) {
+	// The value sets hold values of newer protocol versions.
+	const data = `{
+		"capabilities": {
+			"workspace": {"symbol": {"symbolKind": {"valueSet": [1, 27]}}},
+			"textDocument": {
+				"publishDiagnostics": {"tagSupport": {"valueSet": [1, 2, 3]}},
+				"completion": {
+					"completionItem": {"tagSupport": {"valueSet": [1, 2]}},
+					"completionItemKind": {"valueSet": [1, 2, 3, 26]},
+					"insertTextMode": 3
+				},
+				"documentSymbol": {
+					"symbolKind": {"valueSet": [1, 30]},
+					"tagSupport": {"valueSet": [1, 2]}
+				}
+			}
+		}
+	}`
+	var params InitializeRequestParams
+	if err := DecodeParams([]byte(data), &params); err != nil {
+		t.Fatal(err)
+	}
+	kinds := params.Capabilities.TextDocument.Completion.CompletionItemKind.ValueSet
+	if len(kinds) != 4 || kinds[3] != 26 {
+		t.Errorf("completion item kinds = %v, want the decoded value set", kinds)
+	}
+}
@@ -0,0 +1,118 @@
+package domain
+
+// Window Methods
+const (
+	// MethodWindowShowMessage is the notification method asking the client
+	// to show a message to the user.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#window_showMessage
+	MethodWindowShowMessage Method = "window/showMessage"
+
+	// MethodWindowShowMessageRequest is the request method asking the
+	// client to show a message with actions to the user and to return the
+	// chosen action.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#window_showMessageRequest
+	MethodWindowShowMessageRequest Method = "window/showMessageRequest"
+
+	// MethodWindowLogMessage is the notification method asking the client
+	// to log a message.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#window_logMessage
+	MethodWindowLogMessage Method = "window/logMessage"
+
+	// MethodWindowShowDocument is the request method asking the client to
+	// show a resource, e.g. a document in the editor or a web page in the
+	// browser.
+	//
+	// Microsoft LSP Docs:
+	// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#window_showDocument
+	MethodWindowShowDocument Method = "window/showDocument"
+)
+
+// ShowMessageParams are the parameters of a show message notification.
+type ShowMessageParams struct {
+	// Type is the type of the message.
+	Type MessageType `json:"type"`
+	// Message is the actual message.
+	Message string `json:"message"`
+}
+
+// LogMessageParams are the parameters of a log message notification.
+type LogMessageParams struct {
+	// Type is the type of the message.
+	Type MessageType `json:"type"`
+	// Message is the actual message.
+	Message string `json:"message"`
+}
+
+// ShowMessageRequestParams are the parameters of a show message request.
+type ShowMessageRequestParams struct {
+	// Type is the type of the message.
+	Type MessageType `json:"type"`
+	// Message is the actual message.
+	Message string `json:"message"`
+	// Actions are the actions the user can choose from.
+	Actions []MessageActionItem `json:"actions,omitempty"`
+}
+
+// MessageActionItem is an action of a show message request.
+type MessageActionItem struct {
+	// Title is a short title like 'Retry', 'Open Log' etc.
+	Title string `json:"title"`
+}
+
+// ShowDocumentParams are the parameters of a show document request.
+type ShowDocumentParams struct {
+	// URI is the uri of the resource to show.
+	URI string `json:"uri"`
+	// External shows the resource in an external program, e.g. a web page
+	// in the default browser.
+	External bool `json:"external,omitempty"`
+	// TakeFocus moves the focus to the editor showing the resource.
+	TakeFocus bool `json:"takeFocus,omitempty"`
+	// Selection is the range to select if the resource is a text
+	// document.
+	Selection *Range `json:"selection,omitempty"`
+}
+
+// ShowDocumentResult is the result of a show document request.
+type ShowDocumentResult struct {
+	// Success is whether the resource was shown successfully.
+	Success bool `json:"success"`
+}
+
+// WindowClientCapabilities are the window specific client capabilities.
+type WindowClientCapabilities struct {
+	// WorkDoneProgress is whether the client supports server initiated
+	// progress.
+	WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
+	// ShowMessage are the capabilities specific to the show message
+	// request.
+	ShowMessage *ShowMessageRequestClientCapabilities `json:"showMessage,omitempty"`
+	// ShowDocument are the capabilities specific to the show document
+	// request.
+	ShowDocument *ShowDocumentClientCapabilities `json:"showDocument,omitempty"`
+}
+
+// ShowMessageRequestClientCapabilities are the client capabilities for
+// show message requests.
+type ShowMessageRequestClientCapabilities struct {
+	// MessageActionItem are the capabilities specific to the action items.
+	MessageActionItem *struct {
+		// AdditionalPropertiesSupport is whether the client supports
+		// additional properties in action items which are sent back to
+		// the server.
+		AdditionalPropertiesSupport bool `json:"additionalPropertiesSupport,omitempty"`
+	} `json:"messageActionItem,omitempty"`
+}
+
+// ShowDocumentClientCapabilities are the client capabilities for show
+// document requests.
+type ShowDocumentClientCapabilities struct {
+	// Support is whether the client supports the show document request.
+	Support bool `json:"support"`
+}
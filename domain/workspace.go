@@ -1,5 +1,7 @@
 package domain
 
+import "encoding/json"
+
 // WorkspaceFolder is a workspace folder.
 type WorkspaceFolder struct {
 	// The associated URI for this workspace folder.
@@ -9,3 +11,55 @@ type WorkspaceFolder struct {
 	// workspace folder in the user interface.
 	Name string `json:"name,required"`
 }
+
+// MethodWorkspaceExecuteCommand is the request method used by the client
+// to run a command on the server, e.g. the command of a code action or a
+// code lens.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#workspace_executeCommand
+const MethodWorkspaceExecuteCommand Method = "workspace/executeCommand"
+
+// ExecuteCommandRequest is a request to run a command on the server.
+type ExecuteCommandRequest struct {
+	// ExecuteCommandRequest embeds the Request struct
+	Request
+	// Params are the parameters for the execute command request.
+	Params ExecuteCommandParams `json:"params"`
+}
+
+// ExecuteCommandParams are the parameters of an execute command request.
+type ExecuteCommandParams struct {
+	// Command is the identifier of the command.
+	Command string `json:"command"`
+	// Arguments are the arguments the command was created with.
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+// ExecuteCommandResponse is the response for an execute command request.
+type ExecuteCommandResponse struct {
+	// ExecuteCommandResponse embeds the Response struct
+	Response
+	// Result is the result of the command.
+	Result interface{} `json:"result"`
+}
+
+// Method returns the method for the execute command response
+func (r ExecuteCommandResponse) Method() string {
+	return string(MethodWorkspaceExecuteCommand)
+}
+
+// ExecuteCommandOptions are the server capabilities for executing
+// commands.
+type ExecuteCommandOptions struct {
+	// Commands are the commands executed by the server.
+	Commands []string `json:"commands"`
+}
+
+// ExecuteCommandClientCapabilities are the client capabilities for
+// executing commands.
+type ExecuteCommandClientCapabilities struct {
+	// DynamicRegistration is whether execute command supports dynamic
+	// registration.
+	DynamicRegistration bool `json:"dynamicRegistration,omitempty"`
+}
@@ -0,0 +1,255 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DocumentChange is a change of a workspace edit: either an edit of a text
+// document or a create, rename or delete file operation.
+//
+// Exactly one of the fields is set.
+type DocumentChange struct {
+	// TextDocumentEdit edits a text document.
+	TextDocumentEdit *TextDocumentEdit
+	// CreateFile creates a file.
+	CreateFile *CreateFile
+	// RenameFile renames a file.
+	RenameFile *RenameFile
+	// DeleteFile deletes a file.
+	DeleteFile *DeleteFile
+}
+
+// Resource operation kinds of file operations in a workspace edit.
+const (
+	// ResourceOperationCreate creates a file.
+	ResourceOperationCreate ResourceOperationKind = "create"
+	// ResourceOperationRename renames a file.
+	ResourceOperationRename ResourceOperationKind = "rename"
+	// ResourceOperationDelete deletes a file.
+	ResourceOperationDelete ResourceOperationKind = "delete"
+)
+
+// ResourceOperationKind is the kind of a file operation in a workspace
+// edit.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#resourceOperationKind
+type ResourceOperationKind string
+
+// Kind returns the resource operation kind of the change or the empty kind
+// for a text document edit.
+func (c DocumentChange) Kind() ResourceOperationKind {
+	switch {
+	case c.CreateFile != nil:
+		return ResourceOperationCreate
+	case c.RenameFile != nil:
+		return ResourceOperationRename
+	case c.DeleteFile != nil:
+		return ResourceOperationDelete
+	}
+	return ""
+}
+
+// MarshalJSON encodes the change as the text document edit or the file
+// operation tagged with its kind.
+func (c DocumentChange) MarshalJSON() ([]byte, error) {
+	switch {
+	case c.TextDocumentEdit != nil:
+		return json.Marshal(c.TextDocumentEdit)
+	case c.CreateFile != nil:
+		return json.Marshal(struct {
+			Kind ResourceOperationKind `json:"kind"`
+			*CreateFile
+		}{ResourceOperationCreate, c.CreateFile})
+	case c.RenameFile != nil:
+		return json.Marshal(struct {
+			Kind ResourceOperationKind `json:"kind"`
+			*RenameFile
+		}{ResourceOperationRename, c.RenameFile})
+	case c.DeleteFile != nil:
+		return json.Marshal(struct {
+			Kind ResourceOperationKind `json:"kind"`
+			*DeleteFile
+		}{ResourceOperationDelete, c.DeleteFile})
+	}
+	return nil, errors.New("document change must not be empty")
+}
+
+// UnmarshalJSON decodes the change by its kind.
+func (c *DocumentChange) UnmarshalJSON(data []byte) error {
+	var tag struct {
+		Kind ResourceOperationKind `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return err
+	}
+	*c = DocumentChange{}
+	switch tag.Kind {
+	case "":
+		c.TextDocumentEdit = &TextDocumentEdit{}
+		return json.Unmarshal(data, c.TextDocumentEdit)
+	case ResourceOperationCreate:
+		c.CreateFile = &CreateFile{}
+		return json.Unmarshal(data, c.CreateFile)
+	case ResourceOperationRename:
+		c.RenameFile = &RenameFile{}
+		return json.Unmarshal(data, c.RenameFile)
+	case ResourceOperationDelete:
+		c.DeleteFile = &DeleteFile{}
+		return json.Unmarshal(data, c.DeleteFile)
+	}
+	return fmt.Errorf("unknown document change kind %q", tag.Kind)
+}
+
+// TextDocumentEdit describes the edits of a text document at a version.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textDocumentEdit
+type TextDocumentEdit struct {
+	// TextDocument is the text document to change.
+	TextDocument OptionalVersionedTextDocumentIdentifier `json:"textDocument"`
+	// Edits are the edits to be applied.
+	Edits []AnnotatedTextEdit `json:"edits"`
+}
+
+// OptionalVersionedTextDocumentIdentifier identifies a text document at an
+// optional version.
+type OptionalVersionedTextDocumentIdentifier struct {
+	// URI is the uri of the text document.
+	URI string `json:"uri"`
+	// Version is the version of the text document the edits were computed
+	// for. A nil version means the edits apply to any version.
+	Version *int `json:"version"`
+}
+
+// AnnotatedTextEdit is a text edit with an optional change annotation.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#textEdit
+type AnnotatedTextEdit struct {
+	// AnnotatedTextEdit embeds the TextEdit struct
+	TextEdit
+	// AnnotationID is the id of the change annotation of the edit.
+	AnnotationID string `json:"annotationId,omitempty"`
+}
+
+// CreateFile is an operation creating a file.
+type CreateFile struct {
+	// URI is the resource to create.
+	URI string `json:"uri"`
+	// Options are the options of the operation.
+	Options *CreateFileOptions `json:"options,omitempty"`
+	// AnnotationID is the id of the change annotation of the operation.
+	AnnotationID string `json:"annotationId,omitempty"`
+}
+
+// CreateFileOptions are the options of a create file operation.
+type CreateFileOptions struct {
+	// Overwrite overwrites an existing file. Overwrite wins over
+	// IgnoreIfExists.
+	Overwrite bool `json:"overwrite,omitempty"`
+	// IgnoreIfExists ignores the operation if the file already exists.
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+// RenameFile is an operation renaming a file.
+type RenameFile struct {
+	// OldURI is the resource to rename.
+	OldURI string `json:"oldUri"`
+	// NewURI is the new location of the resource.
+	NewURI string `json:"newUri"`
+	// Options are the options of the operation.
+	Options *RenameFileOptions `json:"options,omitempty"`
+	// AnnotationID is the id of the change annotation of the operation.
+	AnnotationID string `json:"annotationId,omitempty"`
+}
+
+// RenameFileOptions are the options of a rename file operation.
+type RenameFileOptions struct {
+	// Overwrite overwrites the target if it exists. Overwrite wins over
+	// IgnoreIfExists.
+	Overwrite bool `json:"overwrite,omitempty"`
+	// IgnoreIfExists ignores the operation if the target exists.
+	IgnoreIfExists bool `json:"ignoreIfExists,omitempty"`
+}
+
+// DeleteFile is an operation deleting a file.
+type DeleteFile struct {
+	// URI is the file to delete.
+	URI string `json:"uri"`
+	// Options are the options of the operation.
+	Options *DeleteFileOptions `json:"options,omitempty"`
+	// AnnotationID is the id of the change annotation of the operation.
+	AnnotationID string `json:"annotationId,omitempty"`
+}
+
+// DeleteFileOptions are the options of a delete file operation.
+type DeleteFileOptions struct {
+	// Recursive deletes the content recursively if a folder is denoted.
+	Recursive bool `json:"recursive,omitempty"`
+	// IgnoreIfNotExists ignores the operation if the file does not exist.
+	IgnoreIfNotExists bool `json:"ignoreIfNotExists,omitempty"`
+}
+
+// ChangeAnnotation describes a group of changes of a workspace edit, e.g.
+// to ask the user for confirmation.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#changeAnnotation
+type ChangeAnnotation struct {
+	// Label is a human-readable string describing the change. It is
+	// rendered prominently in the user interface.
+	Label string `json:"label"`
+	// NeedsConfirmation is whether the user needs to confirm the change
+	// before it is applied.
+	NeedsConfirmation bool `json:"needsConfirmation,omitempty"`
+	// Description is a human-readable string rendered less prominently in
+	// the user interface.
+	Description string `json:"description,omitempty"`
+}
+
+// Failure handling kinds of a client applying a workspace edit.
+const (
+	// FailureHandlingAbort aborts applying the workspace edit on failure.
+	FailureHandlingAbort FailureHandlingKind = "abort"
+	// FailureHandlingTransactional applies all or none of the changes.
+	FailureHandlingTransactional FailureHandlingKind = "transactional"
+	// FailureHandlingTextOnlyTransactional applies the text changes
+	// transactionally if the edit consists of text changes only.
+	FailureHandlingTextOnlyTransactional FailureHandlingKind = "textOnlyTransactional"
+	// FailureHandlingUndo undoes the changes already applied on failure.
+	FailureHandlingUndo FailureHandlingKind = "undo"
+)
+
+// FailureHandlingKind is how a client handles the failure of applying a
+// workspace edit.
+type FailureHandlingKind string
+
+// WorkspaceEditClientCapabilities are the client capabilities for
+// workspace edits.
+type WorkspaceEditClientCapabilities struct {
+	// DocumentChanges is whether the client supports versioned document
+	// changes in workspace edits.
+	DocumentChanges bool `json:"documentChanges,omitempty"`
+	// ResourceOperations are the resource operations supported by the
+	// client.
+	ResourceOperations []ResourceOperationKind `json:"resourceOperations,omitempty"`
+	// FailureHandling is how the client handles failures.
+	FailureHandling FailureHandlingKind `json:"failureHandling,omitempty"`
+	// NormalizesLineEndings is whether the client normalizes line endings
+	// to the client specific setting.
+	NormalizesLineEndings bool `json:"normalizesLineEndings,omitempty"`
+	// ChangeAnnotationSupport is set if the client supports change
+	// annotations.
+	ChangeAnnotationSupport *ChangeAnnotationSupport `json:"changeAnnotationSupport,omitempty"`
+}
+
+// ChangeAnnotationSupport describes the support of a client for change
+// annotations.
+type ChangeAnnotationSupport struct {
+	// GroupsOnLabel is whether the client groups edits with equal labels
+	// into tree nodes.
+	GroupsOnLabel bool `json:"groupsOnLabel,omitempty"`
+}
@@ -1,13 +1,222 @@
 package main
 
 import (
+	"encoding/json"
+	"os"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/conneroisu/glisp"
 	"github.com/conneroisu/glisp/domain"
 )
 
-// newR returns a new rename handler
-func newR() glisp.HandlerFunc {
+// newInitialize returns a new initialize handler storing the capabilities
+// of the client in the session
+func newInitialize(session *glisp.Session) glisp.HandlerFunc {
+	return func(w glisp.ResponseWriter, r *domain.Request) {
+		var params domain.InitializeRequestParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil {
+			_ = glisp.Reply(w, r.ID, nil, err)
+			return
+		}
+		session.SetClientCapabilities(params.Capabilities)
+		result := domain.NewInitializeResponse(r.ID).Result
+		result.Capabilities.PositionEncoding = session.NegotiatePositionEncoding(
+			domain.PositionEncodingUTF8,
+			domain.PositionEncodingUTF16,
+		)
+		result.Capabilities.RenameProvider = domain.RenameOptions{
+			PrepareProvider: true,
+		}
+		_ = glisp.Reply(w, r.ID, result, nil)
+	}
+}
+
+// documentParams are the params of the didChange and didClose
+// notifications
+type documentParams struct {
+	TextDocument struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version"`
+	} `json:"textDocument"`
+	ContentChanges []domain.TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// newDidOpen returns a new did open handler storing the document and
+// indexing its identifiers
+func newDidOpen(
+	session *glisp.Session,
+	docs *glisp.DocumentStore,
+	index *glisp.OccurrenceIndex,
+) glisp.HandlerFunc {
+	return func(_ glisp.ResponseWriter, r *domain.Request) {
+		var params domain.DidOpenTextDocumentParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil {
+			return
+		}
+		item := params.TextDocument
+		docs.Open(item)
+		index.SetDocument(item.URI, identifiers(item.Text, session.PositionEncoding()))
+	}
+}
+
+// newDidChange returns a new did change handler replacing the text of the
+// document with the full text sent by the client and indexing it again
+func newDidChange(
+	session *glisp.Session,
+	docs *glisp.DocumentStore,
+	index *glisp.OccurrenceIndex,
+) glisp.HandlerFunc {
+	return func(_ glisp.ResponseWriter, r *domain.Request) {
+		var params documentParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil ||
+			len(params.ContentChanges) == 0 {
+			return
+		}
+		uri := params.TextDocument.URI
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		if docs.Update(uri, params.TextDocument.Version, text) {
+			index.SetDocument(uri, identifiers(text, session.PositionEncoding()))
+		}
+	}
+}
+
+// newDidClose returns a new did close handler forgetting the document
+func newDidClose(
+	docs *glisp.DocumentStore,
+	index *glisp.OccurrenceIndex,
+) glisp.HandlerFunc {
+	return func(_ glisp.ResponseWriter, r *domain.Request) {
+		var params documentParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil {
+			return
+		}
+		docs.Close(params.TextDocument.URI)
+		index.RemoveDocument(params.TextDocument.URI)
+	}
+}
+
+// newR returns a new rename handler renaming the occurrences of the symbol
+// in the index adapted to the workspace edit capabilities of the client
+func newR(
+	session *glisp.Session,
+	docs *glisp.DocumentStore,
+	index *glisp.OccurrenceIndex,
+) glisp.HandlerFunc {
+	return func(w glisp.ResponseWriter, r *domain.Request) {
+		var params domain.RenameParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil {
+			_ = glisp.Reply(w, r.ID, nil, err)
+			return
+		}
+		if params.NewName == "" {
+			_ = glisp.Reply(w, r.ID, nil, &domain.Error{
+				Code:    domain.CodeInvalidParams,
+				Message: "the new name must not be empty",
+			})
+			return
+		}
+		edit, ok := index.Rename(
+			params.TextDocument.URI,
+			params.Position,
+			params.NewName,
+			docs.Version,
+		)
+		if !ok {
+			_ = glisp.Reply(w, r.ID, nil, nil)
+			return
+		}
+		adapted, err := glisp.WorkspaceEdit(session.ClientCapabilities(), edit)
+		if err != nil {
+			_ = glisp.Reply(w, r.ID, nil, &domain.Error{
+				Code:    domain.CodeRequestFailed,
+				Message: err.Error(),
+			})
+			return
+		}
+		_ = glisp.Reply(w, r.ID, adapted, nil)
+	}
+}
+
+// newPrepareRename returns a new prepare rename handler checking that
+// there is a symbol in the index to rename
+func newPrepareRename(index *glisp.OccurrenceIndex) glisp.HandlerFunc {
+	return func(w glisp.ResponseWriter, r *domain.Request) {
+		var params domain.PrepareRenameParams
+		if err := domain.DecodeParams(r.Params, &params); err != nil {
+			_ = glisp.Reply(w, r.ID, nil, err)
+			return
+		}
+		if rng := index.PrepareRename(params); rng != nil {
+			_ = glisp.Reply(w, r.ID, rng, nil)
+			return
+		}
+		_ = glisp.Reply(w, r.ID, nil, nil)
+	}
+}
+
+// identifiers returns the identifiers of the text as occurrences of the
+// symbol named like them, the first one defining it
+func identifiers(
+	text string,
+	enc domain.PositionEncodingKind,
+) []glisp.Occurrence {
+	lines := domain.NewLineIndex(text, enc)
+	defined := map[string]bool{}
+	var occurrences []glisp.Occurrence
+	for start := 0; start < len(text); {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		if !unicode.IsLetter(r) && r != '_' {
+			start += size
+			continue
+		}
+		end := start + size
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+				break
+			}
+			end += size
+		}
+		name := text[start:end]
+		occurrences = append(occurrences, glisp.Occurrence{
+			Symbol:     name,
+			Range:      lines.Range(start, end),
+			Definition: !defined[name],
+		})
+		defined[name] = true
+		start = end
+	}
+	return occurrences
+}
+
+// newCompletion returns a new completion handler leaving the documentation
+// of its items to the completion item resolve handler
+func newCompletion() glisp.HandlerFunc {
+	return func(w glisp.ResponseWriter, r *domain.Request) {
+		_ = glisp.Reply(w, r.ID, domain.CompletionList{
+			Items: []domain.CompletionItem{{
+				Label: "lite",
+				Kind:  domain.CompletionItemKindKeyword,
+				Data:  json.RawMessage(`"lite"`),
+			}},
+		}, nil)
+	}
+}
+
+// newCompletionResolve returns a new completion item resolve handler
+// computing the documentation of an item
+func newCompletionResolve() glisp.HandlerFunc {
 	return func(w glisp.ResponseWriter, r *domain.Request) {
+		var item domain.CompletionItem
+		if err := domain.DecodeParams(r.Params, &item); err != nil {
+			_ = glisp.Reply(w, r.ID, nil, err)
+			return
+		}
+		item.Documentation = domain.NewStringDocumentation(
+			"The lite keyword of the lite example server.",
+		)
+		_ = glisp.Reply(w, r.ID, item, nil)
 	}
 }
 
@@ -15,9 +224,20 @@ func newR() glisp.HandlerFunc {
 func main() {
 	server := glisp.DefaultMux
 
-	AddRoutes(server)
+	AddRoutes(server, glisp.NewSession(os.Stdout))
 }
 
-func AddRoutes(server *glisp.ServeMux) {
-	server.Handle(domain.MethodTextDocumentRename, newR())
+// AddRoutes registers the handlers of the lite server sending requests and
+// notifications to the client through the session
+func AddRoutes(server *glisp.ServeMux, session *glisp.Session) {
+	docs := glisp.NewDocumentStore()
+	index := glisp.NewOccurrenceIndex()
+	server.Handle(domain.MethodInitialize, newInitialize(session))
+	server.Handle(domain.MethodRequestTextDocumentDidOpen, newDidOpen(session, docs, index))
+	server.Handle(domain.MethodTextDocumentDidChange, newDidChange(session, docs, index))
+	server.Handle(domain.MethodTextDocumentDidClose, newDidClose(docs, index))
+	server.Handle(domain.MethodTextDocumentRename, newR(session, docs, index))
+	server.Handle(domain.MethodTextDocumentPrepareRename, newPrepareRename(index))
+	server.Handle(domain.MethodRequestTextDocumentCompletion, newCompletion())
+	server.Handle(domain.MethodCompletionItemResolve, newCompletionResolve())
 }
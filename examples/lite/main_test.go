@@ -1 +1,123 @@
 package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/conneroisu/glisp"
+	"github.com/conneroisu/glisp/apply"
+	"github.com/conneroisu/glisp/domain"
+)
+
+// request creates a request with the params encoded as JSON.
+func request(t *testing.T, id int, params interface{}) *domain.Request {
+	t.Helper()
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &domain.Request{RPC: "2.0", ID: id, Params: data}
+}
+
+// result decodes the result of the framed response written to buf.
+func result(t *testing.T, buf *bytes.Buffer, v interface{}) {
+	t.Helper()
+	_, content, ok := strings.Cut(buf.String(), "\r\n\r\n")
+	if !ok {
+		t.Fatalf("response is not framed: %q", buf.String())
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *domain.Error   `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Error != nil {
+		t.Fatalf("error response: %v", response.Error.Message)
+	}
+	if err := json.Unmarshal(response.Result, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	const uri = "file:///main.lite"
+	const text = "let x = 1\nprint(x, y)\n"
+	tests := []struct {
+		name            string
+		documentChanges bool
+	}{
+		{"changes", false},
+		{"document changes", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := glisp.NewSession(io.Discard)
+			docs := glisp.NewDocumentStore()
+			index := glisp.NewOccurrenceIndex()
+
+			var init domain.InitializeRequestParams
+			init.Capabilities.Workspace.WorkspaceEdit.DocumentChanges = tt.documentChanges
+			newInitialize(session)(&bytes.Buffer{}, request(t, 1, init))
+			newDidOpen(session, docs, index)(nil, request(t, 0, domain.DidOpenTextDocumentParams{
+				TextDocument: domain.TextDocumentItem{URI: uri, Version: 2, Text: text},
+			}))
+
+			var params domain.RenameParams
+			params.TextDocument.URI = uri
+			params.Position = domain.Position{Line: 1, Character: 6}
+			params.NewName = "count"
+			var buf bytes.Buffer
+			newR(session, docs, index)(&buf, request(t, 2, params))
+			var edit domain.WorkspaceEdit
+			result(t, &buf, &edit)
+
+			var edits []domain.TextEdit
+			if tt.documentChanges {
+				if len(edit.DocumentChanges) != 1 || edit.Changes != nil {
+					t.Fatalf("edit = %+v, want one document change", edit)
+				}
+				change := edit.DocumentChanges[0].TextDocumentEdit
+				if v := change.TextDocument.Version; v == nil || *v != 2 {
+					t.Errorf("version = %v, want 2", v)
+				}
+				for _, e := range change.Edits {
+					edits = append(edits, e.TextEdit)
+				}
+			} else {
+				if edit.DocumentChanges != nil {
+					t.Fatalf("edit = %+v, want changes", edit)
+				}
+				edits = edit.Changes[uri]
+			}
+			got, err := apply.Edits(text, edits, session.PositionEncoding())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "let count = 1\nprint(count, y)\n"; got != want {
+				t.Errorf("renamed text = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestInitializeNewerClient(t *testing.T) {
+	session := glisp.NewSession(io.Discard)
+	r := &domain.Request{RPC: "2.0", ID: 1, Params: json.RawMessage(`{
+		"capabilities": {"textDocument": {"completion": {
+			"completionItemKind": {"valueSet": [1, 25, 26]}
+		}}}
+	}`)}
+	var buf bytes.Buffer
+	newInitialize(session)(&buf, r)
+	var initialized domain.InitializeResult
+	result(t, &buf, &initialized)
+	kinds := session.ClientCapabilities().TextDocument.Completion.CompletionItemKind.ValueSet
+	if len(kinds) != 3 {
+		t.Errorf("completion item kinds = %v, want the value set of the client", kinds)
+	}
+}
@@ -0,0 +1,263 @@
+// Package fuzzy matches and ranks completion candidates against the text
+// typed by the user.
+//
+// Scoring follows the approach of editors like VS Code and fzf: matched
+// characters score points, gaps between them cost points and matches at
+// word boundaries, camelCase humps and the start of the candidate earn
+// bonuses.
+package fuzzy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+const (
+	// scoreMatch is the score of a matched character.
+	scoreMatch = 16
+	// scoreGapStart is the penalty for starting a gap between matches.
+	scoreGapStart = -3
+	// scoreGapExtension is the penalty for every further character in a gap.
+	scoreGapExtension = -1
+	// scoreLeadingGapMax is the maximum penalty for unmatched characters
+	// before the first match.
+	scoreLeadingGapMax = 5
+	// bonusStart is the bonus for matching the first character of the
+	// candidate.
+	bonusStart = 10
+	// bonusBoundary is the bonus for matching the first character after a
+	// separator like `_`, `-`, `.`, `/` or a space.
+	bonusBoundary = 8
+	// bonusCamel is the bonus for matching a camelCase hump or the start of
+	// a number.
+	bonusCamel = 7
+	// bonusConsecutive is the bonus for matching right after the previous
+	// match.
+	bonusConsecutive = 4
+	// bonusCase is the bonus for matching a character in the same case.
+	bonusCase = 1
+	// firstCharMultiplier multiplies the position bonus of the first
+	// pattern character.
+	firstCharMultiplier = 2
+)
+
+// noMatch marks impossible states in the scoring matrix.
+//
+// It is far enough from the minimum int that adding the penalties of a gap
+// never wraps around where int is 32 bits wide.
+const noMatch = math.MinInt32 / 2
+
+// Result is the result of matching a pattern against a candidate.
+type Result struct {
+	// Score is the score of the match, higher is better.
+	Score int
+	// Positions are the byte offsets of the matched characters in the
+	// candidate, e.g. for highlighting. EncodedPositions converts them to
+	// the code units of a position encoding.
+	Positions []int
+}
+
+// EncodedPositions returns the positions of the matched characters in the
+// candidate counted in code units of the position encoding.
+func (r Result) EncodedPositions(
+	candidate string,
+	enc domain.PositionEncodingKind,
+) []int {
+	positions := make([]int, len(r.Positions))
+	for i, offset := range r.Positions {
+		positions[i] = domain.EncodedLen(candidate[:offset], enc)
+	}
+	return positions
+}
+
+// Match matches the pattern against the candidate ignoring case.
+//
+// The characters of the pattern must appear in the candidate in order. ok
+// is false if they do not. An empty pattern matches every candidate with a
+// zero score.
+func Match(pattern, candidate string) (result Result, ok bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return Result{}, true
+	}
+	c, offsets := runes(candidate)
+	n, m := len(p), len(c)
+	if n > m {
+		return Result{}, false
+	}
+	bonuses := make([]int, m)
+	for j := range c {
+		bonuses[j] = bonus(c, j)
+	}
+	// scores[i][j] is the best score of matching p[:i+1] with p[i] at c[j]
+	// and prev[i][j] is the position of p[i-1] in that match.
+	scores := make([][]int, n)
+	prev := make([][]int, n)
+	for i := range p {
+		scores[i] = make([]int, m)
+		prev[i] = make([]int, m)
+		carry, carryFrom := noMatch, -1
+		for j := range c {
+			scores[i][j] = noMatch
+			if i > 0 && j >= 2 {
+				if s := scores[i-1][j-2]; s != noMatch &&
+					(carry == noMatch || s+scoreGapStart > carry+scoreGapExtension) {
+					carry, carryFrom = s+scoreGapStart, j-2
+				} else if carry != noMatch {
+					carry += scoreGapExtension
+				}
+			}
+			if !equalFold(p[i], c[j]) {
+				continue
+			}
+			char := scoreMatch
+			if p[i] == c[j] {
+				char += bonusCase
+			}
+			if i == 0 {
+				scores[i][j] = char + bonuses[j]*firstCharMultiplier -
+					min(j, scoreLeadingGapMax)
+				prev[i][j] = -1
+				continue
+			}
+			best, from := carry, carryFrom
+			if j >= 1 {
+				if s := scores[i-1][j-1]; s != noMatch {
+					consecutive := s + max(bonusConsecutive, bonuses[j])
+					if consecutive >= best {
+						best, from = consecutive, j-1
+					}
+				}
+			}
+			if best == noMatch {
+				continue
+			}
+			scores[i][j] = best + char + bonuses[j]
+			prev[i][j] = from
+		}
+	}
+	end, best := -1, noMatch
+	for j, s := range scores[n-1] {
+		if s > best {
+			end, best = j, s
+		}
+	}
+	if end < 0 {
+		return Result{}, false
+	}
+	positions := make([]int, n)
+	for i, j := n-1, end; i >= 0; i-- {
+		positions[i] = offsets[j]
+		j = prev[i][j]
+	}
+	return Result{Score: best, Positions: positions}, true
+}
+
+// Ranked is a candidate matched by Find.
+type Ranked struct {
+	// Result is the result of matching the candidate.
+	Result
+	// Index is the index of the candidate in the candidates passed to Find.
+	Index int
+}
+
+// Find matches the pattern against all candidates and returns the matching
+// ones ordered by descending score.
+//
+// Candidates with equal scores are ordered by length and then by their
+// original order.
+func Find(pattern string, candidates []string) []Ranked {
+	ranked := []Ranked{}
+	for i, candidate := range candidates {
+		if result, ok := Match(pattern, candidate); ok {
+			ranked = append(ranked, Ranked{Result: result, Index: i})
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		if ranked[a].Score != ranked[b].Score {
+			return ranked[a].Score > ranked[b].Score
+		}
+		return len(candidates[ranked[a].Index]) < len(candidates[ranked[b].Index])
+	})
+	return ranked
+}
+
+// Rank filters the completion items by the pattern and orders them by
+// score keeping at most limit items. A limit of zero or less keeps all
+// matching items.
+//
+// Items are matched by their filter text or their label if it is empty.
+// The filter text of the kept items is set to the text they were matched
+// by and their sort text to their rank so the client keeps the order. The
+// list is incomplete if items were dropped because of the limit so the
+// client asks again as the user keeps typing.
+func Rank(
+	pattern string,
+	items []domain.CompletionItem,
+	limit int,
+) domain.CompletionList {
+	texts := make([]string, len(items))
+	for i, item := range items {
+		texts[i] = item.FilterText
+		if texts[i] == "" {
+			texts[i] = item.Label
+		}
+	}
+	ranked := Find(pattern, texts)
+	list := domain.CompletionList{Items: []domain.CompletionItem{}}
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+		list.IsIncomplete = true
+	}
+	width := len(fmt.Sprint(len(ranked)))
+	for rank, r := range ranked {
+		item := items[r.Index]
+		item.FilterText = texts[r.Index]
+		item.SortText = fmt.Sprintf("%0*d", width, rank)
+		list.Items = append(list.Items, item)
+	}
+	return list
+}
+
+// runes returns the runes of s with their byte offsets.
+func runes(s string) ([]rune, []int) {
+	rs := make([]rune, 0, utf8.RuneCountInString(s))
+	offsets := make([]int, 0, cap(rs))
+	for offset, r := range s {
+		rs = append(rs, r)
+		offsets = append(offsets, offset)
+	}
+	return rs, offsets
+}
+
+// bonus returns the position bonus of matching the character c[j].
+func bonus(c []rune, j int) int {
+	if j == 0 {
+		return bonusStart
+	}
+	prev, cur := c[j-1], c[j]
+	switch {
+	case !isWord(prev) && isWord(cur):
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamel
+	case !unicode.IsDigit(prev) && unicode.IsDigit(cur):
+		return bonusCamel
+	}
+	return 0
+}
+
+// isWord reports whether r is part of a word.
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// equalFold reports whether a and b are equal ignoring case.
+func equalFold(a, b rune) bool {
+	return a == b || unicode.ToLower(a) == unicode.ToLower(b)
+}
@@ -0,0 +1,145 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		candidate string
+		ok        bool
+		positions []int
+	}{
+		{"", "anything", true, nil},
+		{"abc", "abc", true, []int{0, 1, 2}},
+		{"ABC", "abc", true, []int{0, 1, 2}},
+		{"fb", "fooBar", true, []int{0, 3}},
+		{"fb", "foo_bar", true, []int{0, 4}},
+		{"cba", "abc", false, nil},
+		{"abcd", "abc", false, nil},
+		{"é", "café", true, []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.candidate, func(t *testing.T) {
+			result, ok := Match(tt.pattern, tt.candidate)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(result.Positions, tt.positions) {
+				t.Errorf("positions = %v, want %v", result.Positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFindOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		candidates []string
+		want       []string
+	}{
+		{
+			name:       "prefix before infix",
+			pattern:    "map",
+			candidates: []string{"bitmap", "mapper"},
+			want:       []string{"mapper", "bitmap"},
+		},
+		{
+			name:       "consecutive before scattered",
+			pattern:    "get",
+			candidates: []string{"gadgetry", "getter"},
+			want:       []string{"getter", "gadgetry"},
+		},
+		{
+			name:       "camel humps before scattered",
+			pattern:    "gu",
+			candidates: []string{"glue", "getUser"},
+			want:       []string{"getUser", "glue"},
+		},
+		{
+			name:       "word boundary before inner match",
+			pattern:    "b",
+			candidates: []string{"abc", "a_bc"},
+			want:       []string{"a_bc", "abc"},
+		},
+		{
+			name:       "same case before other case",
+			pattern:    "Foo",
+			candidates: []string{"foo", "Foo"},
+			want:       []string{"Foo", "foo"},
+		},
+		{
+			name:       "shorter on equal score",
+			pattern:    "ab",
+			candidates: []string{"abcd", "ab", "abc"},
+			want:       []string{"ab", "abc", "abcd"},
+		},
+		{
+			name:       "drops non matches",
+			pattern:    "xyz",
+			candidates: []string{"abc", "xaybzc"},
+			want:       []string{"xaybzc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ranked := range Find(tt.pattern, tt.candidates) {
+				got = append(got, tt.candidates[ranked.Index])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchLongGap(t *testing.T) {
+	// A long gap must lower the score, never wrap it around.
+	candidate := "a" + string(make([]byte, 1<<12)) + "b"
+	near, _ := Match("ab", "a_b")
+	far, ok := Match("ab", candidate)
+	if !ok || far.Score >= near.Score {
+		t.Errorf("score of long gap = %d, want below %d", far.Score, near.Score)
+	}
+}
+
+func TestEncodedPositions(t *testing.T) {
+	result, ok := Match("xy", "😀x😀y")
+	if !ok {
+		t.Fatal("no match")
+	}
+	tests := []struct {
+		enc  domain.PositionEncodingKind
+		want []int
+	}{
+		{domain.PositionEncodingUTF8, []int{4, 9}},
+		{domain.PositionEncodingUTF16, []int{2, 5}},
+		{domain.PositionEncodingUTF32, []int{1, 3}},
+	}
+	for _, tt := range tests {
+		if got := result.EncodedPositions("😀x😀y", tt.enc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: positions = %v, want %v", tt.enc, got, tt.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	items := []domain.CompletionItem{
+		{Label: "bitmap"},
+		{Label: "m", FilterText: "mapper"},
+		{Label: "other"},
+	}
+	list := Rank("map", items, 1)
+	if !list.IsIncomplete || len(list.Items) != 1 {
+		t.Fatalf("list = %+v, want one item and incomplete", list)
+	}
+	if item := list.Items[0]; item.Label != "m" || item.FilterText != "mapper" || item.SortText != "0" {
+		t.Errorf("item = %+v", item)
+	}
+}
@@ -0,0 +1,144 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// hierarchyKey is the data envelope key of call and type hierarchy items.
+const hierarchyKey = "hierarchy"
+
+// SetHierarchyKey stores the key identifying a call or type hierarchy item
+// on the server in the data of the item.
+//
+// Clients send the item back in the follow-up requests of the hierarchy
+// where CallHierarchy and TypeHierarchy decode the key again, so servers
+// need no item identity scheme of their own.
+func SetHierarchyKey[K any](data *json.RawMessage, key K) error {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	*data, err = wrapData(hierarchyKey, encoded)
+	return err
+}
+
+// HierarchyKey returns the key stored in the data of a hierarchy item by
+// SetHierarchyKey.
+//
+// An error with the CodeInvalidParams code is returned if the data holds
+// no key of type K.
+func HierarchyKey[K any](data json.RawMessage) (K, error) {
+	var key K
+	envelope, inner, ok := unwrapData(data)
+	if !ok || envelope != hierarchyKey {
+		return key, &domain.Error{
+			Code:    domain.CodeInvalidParams,
+			Message: "hierarchy item has no key",
+		}
+	}
+	if err := json.Unmarshal(inner, &key); err != nil {
+		return key, &domain.Error{
+			Code:    domain.CodeInvalidParams,
+			Message: fmt.Sprintf("invalid hierarchy item key: %v", err),
+		}
+	}
+	return key, nil
+}
+
+// CallHierarchy answers the follow-up requests of a call hierarchy for
+// items whose data holds a key of type K set by SetHierarchyKey.
+type CallHierarchy[K any] struct {
+	// Incoming returns the calls of the item with the key.
+	Incoming func(
+		ctx context.Context,
+		key K,
+		item domain.CallHierarchyItem,
+	) ([]domain.CallHierarchyIncomingCall, error)
+	// Outgoing returns the calls made by the item with the key.
+	Outgoing func(
+		ctx context.Context,
+		key K,
+		item domain.CallHierarchyItem,
+	) ([]domain.CallHierarchyOutgoingCall, error)
+}
+
+// IncomingCalls answers an incoming calls request.
+func (h CallHierarchy[K]) IncomingCalls(
+	ctx context.Context,
+	params domain.CallHierarchyIncomingCallsParams,
+) ([]domain.CallHierarchyIncomingCall, error) {
+	key, err := HierarchyKey[K](params.Item.Data)
+	if err != nil {
+		return nil, err
+	}
+	if h.Incoming == nil {
+		return []domain.CallHierarchyIncomingCall{}, nil
+	}
+	return h.Incoming(ctx, key, params.Item)
+}
+
+// OutgoingCalls answers an outgoing calls request.
+func (h CallHierarchy[K]) OutgoingCalls(
+	ctx context.Context,
+	params domain.CallHierarchyOutgoingCallsParams,
+) ([]domain.CallHierarchyOutgoingCall, error) {
+	key, err := HierarchyKey[K](params.Item.Data)
+	if err != nil {
+		return nil, err
+	}
+	if h.Outgoing == nil {
+		return []domain.CallHierarchyOutgoingCall{}, nil
+	}
+	return h.Outgoing(ctx, key, params.Item)
+}
+
+// TypeHierarchy answers the follow-up requests of a type hierarchy for
+// items whose data holds a key of type K set by SetHierarchyKey.
+type TypeHierarchy[K any] struct {
+	// Super returns the supertypes of the item with the key.
+	Super func(
+		ctx context.Context,
+		key K,
+		item domain.TypeHierarchyItem,
+	) ([]domain.TypeHierarchyItem, error)
+	// Sub returns the subtypes of the item with the key.
+	Sub func(
+		ctx context.Context,
+		key K,
+		item domain.TypeHierarchyItem,
+	) ([]domain.TypeHierarchyItem, error)
+}
+
+// Supertypes answers a supertypes request.
+func (h TypeHierarchy[K]) Supertypes(
+	ctx context.Context,
+	params domain.TypeHierarchySupertypesParams,
+) ([]domain.TypeHierarchyItem, error) {
+	key, err := HierarchyKey[K](params.Item.Data)
+	if err != nil {
+		return nil, err
+	}
+	if h.Super == nil {
+		return []domain.TypeHierarchyItem{}, nil
+	}
+	return h.Super(ctx, key, params.Item)
+}
+
+// Subtypes answers a subtypes request.
+func (h TypeHierarchy[K]) Subtypes(
+	ctx context.Context,
+	params domain.TypeHierarchySubtypesParams,
+) ([]domain.TypeHierarchyItem, error) {
+	key, err := HierarchyKey[K](params.Item.Data)
+	if err != nil {
+		return nil, err
+	}
+	if h.Sub == nil {
+		return []domain.TypeHierarchyItem{}, nil
+	}
+	return h.Sub(ctx, key, params.Item)
+}
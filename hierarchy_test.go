@@ -0,0 +1,98 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// symbolID is a hierarchy key identifying a symbol by package and name.
+type symbolID struct {
+	Package string `json:"package"`
+	Name    string `json:"name"`
+}
+
+func TestHierarchyKey(t *testing.T) {
+	var data json.RawMessage
+	want := symbolID{Package: "glisp", Name: "Session"}
+	if err := SetHierarchyKey(&data, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := HierarchyKey[symbolID](data)
+	if err != nil || got != want {
+		t.Errorf("HierarchyKey = %+v, %v, want %+v", got, err, want)
+	}
+
+	tests := []struct {
+		name string
+		data json.RawMessage
+	}{
+		{"no data", nil},
+		{"foreign data", json.RawMessage(`{"id":1}`)},
+		{"other envelope", json.RawMessage(`{"glisp":"lens","data":{}}`)},
+		{"wrong key type", json.RawMessage(`{"glisp":"hierarchy","data":"name"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := HierarchyKey[symbolID](tt.data)
+			var rpcErr *domain.Error
+			if !errors.As(err, &rpcErr) || rpcErr.Code != domain.CodeInvalidParams {
+				t.Errorf("err = %v, want invalid params", err)
+			}
+		})
+	}
+}
+
+func TestCallHierarchy(t *testing.T) {
+	item := domain.CallHierarchyItem{Name: "Serve"}
+	if err := SetHierarchyKey(&item.Data, symbolID{Package: "glisp", Name: "Serve"}); err != nil {
+		t.Fatal(err)
+	}
+	h := CallHierarchy[symbolID]{
+		Incoming: func(
+			_ context.Context,
+			key symbolID,
+			_ domain.CallHierarchyItem,
+		) ([]domain.CallHierarchyIncomingCall, error) {
+			return []domain.CallHierarchyIncomingCall{{From: domain.CallHierarchyItem{Name: "main calls " + key.Name}}}, nil
+		},
+	}
+	incoming, err := h.IncomingCalls(context.Background(), domain.CallHierarchyIncomingCallsParams{Item: item})
+	if err != nil || len(incoming) != 1 || incoming[0].From.Name != "main calls Serve" {
+		t.Errorf("IncomingCalls = %+v, %v", incoming, err)
+	}
+	outgoing, err := h.OutgoingCalls(context.Background(), domain.CallHierarchyOutgoingCallsParams{Item: item})
+	if err != nil || outgoing == nil || len(outgoing) != 0 {
+		t.Errorf("OutgoingCalls without a provider = %#v, %v, want an empty list", outgoing, err)
+	}
+	if _, err := h.IncomingCalls(context.Background(), domain.CallHierarchyIncomingCallsParams{}); err == nil {
+		t.Error("IncomingCalls accepted an item without a key")
+	}
+}
+
+func TestTypeHierarchy(t *testing.T) {
+	item := domain.TypeHierarchyItem{Name: "Reader"}
+	if err := SetHierarchyKey(&item.Data, "io.Reader"); err != nil {
+		t.Fatal(err)
+	}
+	h := TypeHierarchy[string]{
+		Sub: func(
+			_ context.Context,
+			key string,
+			_ domain.TypeHierarchyItem,
+		) ([]domain.TypeHierarchyItem, error) {
+			return []domain.TypeHierarchyItem{{Name: key + " implementation"}}, nil
+		},
+	}
+	sub, err := h.Subtypes(context.Background(), domain.TypeHierarchySubtypesParams{Item: item})
+	if err != nil || len(sub) != 1 || sub[0].Name != "io.Reader implementation" {
+		t.Errorf("Subtypes = %+v, %v", sub, err)
+	}
+	super, err := h.Supertypes(context.Background(), domain.TypeHierarchySupertypesParams{Item: item})
+	if err != nil || super == nil || len(super) != 0 {
+		t.Errorf("Supertypes without a provider = %#v, %v, want an empty list", super, err)
+	}
+}
@@ -0,0 +1,110 @@
+package glisp
+
+import (
+	"context"
+	"sort"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// InlayHintFunc computes the inlay hints of a range of a document.
+//
+// Hints may omit their tooltips, locations and commands which are then
+// computed by the resolver of the provider once the client asks for them.
+type InlayHintFunc func(
+	ctx context.Context,
+	params domain.InlayHintParams,
+) ([]domain.InlayHint, error)
+
+// InlayHintResolveFunc computes the missing properties of an inlay hint.
+type InlayHintResolveFunc func(
+	ctx context.Context,
+	hint domain.InlayHint,
+) (domain.InlayHint, error)
+
+// inlayHintProvider is an inlay hint provider registered by name.
+type inlayHintProvider struct {
+	hints   InlayHintFunc
+	resolve InlayHintResolveFunc
+}
+
+// InlayHints routes inlay hint and inlay hint resolve requests to the
+// registered providers, e.g. one for parameter names and one for inferred
+// types.
+type InlayHints struct {
+	providers *registry[inlayHintProvider]
+}
+
+// NewInlayHints creates a new empty inlay hint registry.
+func NewInlayHints() *InlayHints {
+	return &InlayHints{
+		providers: newRegistry(func(provider inlayHintProvider) bool {
+			return provider.resolve != nil
+		}),
+	}
+}
+
+// Handle registers the inlay hint provider with the given name.
+//
+// resolve may be nil if the provider always returns complete hints.
+func (h *InlayHints) Handle(
+	name string,
+	hints InlayHintFunc,
+	resolve InlayHintResolveFunc,
+) {
+	h.providers.handle(name, func(provider *inlayHintProvider) {
+		*provider = inlayHintProvider{hints: hints, resolve: resolve}
+	})
+}
+
+// Options returns the inlay hint server capabilities of the registry.
+func (h *InlayHints) Options() domain.InlayHintOptions {
+	return domain.InlayHintOptions{ResolveProvider: h.providers.resolveProvider()}
+}
+
+// InlayHints answers an inlay hint request with the hints of all providers
+// inside the requested range ordered by position.
+//
+// The data of the hints of providers with a resolver is wrapped so Resolve
+// can route them back to their provider.
+func (h *InlayHints) InlayHints(
+	ctx context.Context,
+	params domain.InlayHintParams,
+) ([]domain.InlayHint, error) {
+	names, providers := h.providers.sorted()
+	all := []domain.InlayHint{}
+	for i, provider := range providers {
+		hints, err := provider.hints(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, hint := range hints {
+			if !params.Range.Contains(hint.Position) {
+				continue
+			}
+			hint.Data, err = h.providers.wrap(names[i], provider, hint.Data)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, hint)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Position.Compare(all[j].Position) < 0
+	})
+	return all, nil
+}
+
+// Resolve answers an inlay hint resolve request by calling the resolver of
+// the provider of the hint.
+func (h *InlayHints) Resolve(
+	ctx context.Context,
+	hint domain.InlayHint,
+) (domain.InlayHint, error) {
+	provider, data, ok := h.providers.unwrap(hint.Data)
+	if !ok {
+		return hint, nil
+	}
+	hint.Data = data
+	return provider.resolve(ctx, hint)
+}
@@ -0,0 +1,70 @@
+package glisp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestInlayHints(t *testing.T) {
+	hint := func(line, character int, label string) domain.InlayHint {
+		return domain.InlayHint{
+			Position: domain.Position{Line: line, Character: character},
+			Label:    domain.InlayHintLabel{Text: label},
+		}
+	}
+	hints := func(hints ...domain.InlayHint) InlayHintFunc {
+		return func(context.Context, domain.InlayHintParams) ([]domain.InlayHint, error) {
+			return hints, nil
+		}
+	}
+	resolve := func(_ context.Context, hint domain.InlayHint) (domain.InlayHint, error) {
+		hint.Tooltip = &domain.MarkupContent{Kind: domain.MarkupKindPlainText, Value: hint.Label.Text}
+		return hint, nil
+	}
+	h := NewInlayHints()
+	h.Handle("types", hints(hint(2, 0, "int"), hint(9, 0, "outside")), resolve)
+	h.Handle("names", hints(hint(1, 4, "x:"), hint(2, 0, "y:")), nil)
+	if !h.Options().ResolveProvider {
+		t.Error("registry with a resolver does not resolve hints")
+	}
+
+	params := domain.InlayHintParams{Range: span(0, 0, 0)}
+	params.Range.End = domain.Position{Line: 5}
+	got, err := h.InlayHints(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		label   string
+		wrapped bool
+	}{
+		{"x:", false},
+		{"y:", false},
+		{"int", true},
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("got %d hints, want %d", len(got), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if got[i].Label.Text != tt.label {
+				t.Fatalf("hint %d = %q, want %q", i, got[i].Label.Text, tt.label)
+			}
+			if wrapped := got[i].Data != nil; wrapped != tt.wrapped {
+				t.Errorf("wrapped = %v, want %v", wrapped, tt.wrapped)
+			}
+			resolved, err := h.Resolve(context.Background(), got[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (resolved.Tooltip != nil) != tt.wrapped {
+				t.Errorf("tooltip = %+v, want resolved %v", resolved.Tooltip, tt.wrapped)
+			}
+			if resolved.Data != nil {
+				t.Errorf("resolved data = %s, want unwrapped", resolved.Data)
+			}
+		})
+	}
+}
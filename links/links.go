@@ -0,0 +1,97 @@
+// Package links detects the links in the text of a document so servers
+// can answer document link requests without parsing the language.
+//
+// URLs with a scheme like `https://` are linked as they are. Relative file
+// paths starting with `./` or `../` are resolved against the uri of the
+// document.
+package links
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+var (
+	// urlPattern matches URLs with a scheme.
+	urlPattern = regexp.MustCompile(`\b(?:https?|ftp|file)://[^\s<>"'` + "`" + `]+`)
+	// pathPattern matches relative file paths after a delimiter.
+	pathPattern = regexp.MustCompile(`(?:^|[\s"'(<\[=:,` + "`" + `])(\.\.?/[^\s"'()<>\[\]{}` + "`" + `]+)`)
+)
+
+// Detect returns the links found in the text of the document at uri.
+//
+// The ranges of the links are counted in the encoding and the links are
+// ordered by position.
+func Detect(
+	uri, text string,
+	enc domain.PositionEncodingKind,
+) []domain.DocumentLink {
+	index := domain.NewLineIndex(text, enc)
+	found := []domain.DocumentLink{}
+	taken := []int{}
+	for _, m := range urlPattern.FindAllStringIndex(text, -1) {
+		start, end := m[0], m[0]+len(trimTrailing(text[m[0]:m[1]]))
+		found = append(found, domain.DocumentLink{
+			Range:  index.Range(start, end),
+			Target: text[start:end],
+		})
+		taken = append(taken, start, end)
+	}
+	base, err := url.Parse(uri)
+	if err != nil {
+		return found
+	}
+	for _, m := range pathPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[2], m[2]+len(trimTrailing(text[m[2]:m[3]]))
+		if overlaps(taken, start, end) {
+			continue
+		}
+		target := base.ResolveReference(&url.URL{Path: text[start:end]})
+		found = append(found, domain.DocumentLink{
+			Range:  index.Range(start, end),
+			Target: target.String(),
+		})
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Range.Start.Compare(found[j].Range.Start) < 0
+	})
+	return found
+}
+
+// trimTrailing removes trailing punctuation which usually ends the
+// sentence around a link and closing brackets without an opening one in
+// the link.
+func trimTrailing(link string) string {
+	for len(link) > 0 {
+		switch c := link[len(link)-1]; c {
+		case '.', ',', ';', ':', '!', '?', '*', '_':
+		case ')':
+			if strings.Count(link, "(") >= strings.Count(link, ")") {
+				return link
+			}
+		case ']':
+			if strings.Count(link, "[") >= strings.Count(link, "]") {
+				return link
+			}
+		default:
+			return link
+		}
+		link = link[:len(link)-1]
+	}
+	return link
+}
+
+// overlaps reports whether the byte range overlaps one of the taken
+// ranges given as consecutive start and end offsets.
+func overlaps(taken []int, start, end int) bool {
+	for i := 0; i < len(taken); i += 2 {
+		if start < taken[i+1] && taken[i] < end {
+			return true
+		}
+	}
+	return false
+}
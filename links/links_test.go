@@ -0,0 +1,88 @@
+package links
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestDetect(t *testing.T) {
+	const uri = "file:///repo/docs/guide.md"
+	tests := []struct {
+		name string
+		text string
+		// want are the linked texts and their targets in order.
+		want [][2]string
+	}{
+		{
+			name: "url",
+			text: "see https://go.dev/doc for more",
+			want: [][2]string{{"https://go.dev/doc", "https://go.dev/doc"}},
+		},
+		{
+			name: "trailing punctuation",
+			text: "Read https://go.dev/ref/spec. Or ftp://x.org/a,",
+			want: [][2]string{
+				{"https://go.dev/ref/spec", "https://go.dev/ref/spec"},
+				{"ftp://x.org/a", "ftp://x.org/a"},
+			},
+		},
+		{
+			name: "balanced parentheses",
+			text: "(https://en.wikipedia.org/wiki/Go_(language))",
+			want: [][2]string{{
+				"https://en.wikipedia.org/wiki/Go_(language)",
+				"https://en.wikipedia.org/wiki/Go_(language)",
+			}},
+		},
+		{
+			name: "markdown link",
+			text: "[spec](https://go.dev/ref/spec)",
+			want: [][2]string{{"https://go.dev/ref/spec", "https://go.dev/ref/spec"}},
+		},
+		{
+			name: "relative paths",
+			text: "[api](./api.md) and \"../README.md\"",
+			want: [][2]string{
+				{"./api.md", "file:///repo/docs/api.md"},
+				{"../README.md", "file:///repo/README.md"},
+			},
+		},
+		{
+			name: "path inside url",
+			text: "file:///repo/./a.md",
+			want: [][2]string{{"file:///repo/./a.md", "file:///repo/./a.md"}},
+		},
+		{
+			name: "path without delimiter",
+			text: "a./b.md x/../y",
+		},
+		{
+			name: "multiple lines",
+			text: "one\n  ./two.go:",
+			want: [][2]string{{"./two.go", "file:///repo/docs/two.go"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := Detect(uri, tt.text, domain.PositionEncodingUTF16)
+			if len(found) != len(tt.want) {
+				t.Fatalf("found %+v, want %q", found, tt.want)
+			}
+			for i, link := range found {
+				start, err := domain.OffsetAt(tt.text, link.Range.Start, domain.PositionEncodingUTF16)
+				if err != nil {
+					t.Fatal(err)
+				}
+				end, err := domain.OffsetAt(tt.text, link.Range.End, domain.PositionEncodingUTF16)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := [2]string{tt.text[start:end], link.Target}
+				if got != tt.want[i] {
+					t.Errorf("link %d = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
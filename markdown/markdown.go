@@ -0,0 +1,224 @@
+// Package markdown builds markdown content for hovers and documentation
+// together with its plain text equivalent for clients which cannot render
+// markdown.
+package markdown
+
+import (
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// specialChars are the characters escaped in markdown text.
+const specialChars = "\\`*_{}[]()#+-!|<>~"
+
+// Builder builds a markdown document and its plain text equivalent.
+//
+// Inline elements are collected into a paragraph which is ended by the next
+// block element. The zero value is an empty document ready to use.
+type Builder struct {
+	blocks []block
+	inline struct {
+		markdown strings.Builder
+		plain    strings.Builder
+	}
+}
+
+// block is a block of the document in both renderings.
+type block struct {
+	markdown string
+	plain    string
+}
+
+// New creates a new empty markdown builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Text appends escaped text to the current paragraph.
+func (b *Builder) Text(text string) *Builder {
+	return b.inlines(Escape(text), text)
+}
+
+// Bold appends bold text to the current paragraph.
+func (b *Builder) Bold(text string) *Builder {
+	return b.inlines("**"+Escape(text)+"**", text)
+}
+
+// Italic appends italic text to the current paragraph.
+func (b *Builder) Italic(text string) *Builder {
+	return b.inlines("_"+Escape(text)+"_", text)
+}
+
+// Code appends inline code to the current paragraph.
+func (b *Builder) Code(code string) *Builder {
+	fence := strings.Repeat("`", longestRun(code, '`')+1)
+	padding := ""
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		padding = " "
+	}
+	return b.inlines(fence+padding+code+padding+fence, code)
+}
+
+// Link appends a link with the given text to the current paragraph.
+//
+// The plain text of a link is its text followed by the url in parentheses.
+func (b *Builder) Link(text, url string) *Builder {
+	plain := text + " (" + url + ")"
+	if text == "" || text == url {
+		plain = url
+	}
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+	return b.inlines("["+Escape(text)+"]("+url+")", plain)
+}
+
+// Paragraph appends the escaped text as a paragraph of its own.
+func (b *Builder) Paragraph(text string) *Builder {
+	b.flush()
+	b.Text(text)
+	b.flush()
+	return b
+}
+
+// Heading appends a heading of the given level between 1 and 6.
+func (b *Builder) Heading(level int, text string) *Builder {
+	level = min(max(level, 1), 6)
+	return b.blockOf(strings.Repeat("#", level)+" "+Escape(text), text)
+}
+
+// CodeBlock appends a fenced code block highlighted as the given language.
+func (b *Builder) CodeBlock(language, code string) *Builder {
+	fence := strings.Repeat("`", max(longestRun(code, '`')+1, 3))
+	code = strings.TrimSuffix(code, "\n")
+	return b.blockOf(fence+language+"\n"+code+"\n"+fence, code)
+}
+
+// List appends a bullet list of escaped items.
+func (b *Builder) List(items ...string) *Builder {
+	markdown := make([]string, len(items))
+	plain := make([]string, len(items))
+	for i, item := range items {
+		markdown[i] = "- " + Escape(item)
+		plain[i] = "- " + item
+	}
+	return b.blockOf(strings.Join(markdown, "\n"), strings.Join(plain, "\n"))
+}
+
+// Rule appends a horizontal rule.
+func (b *Builder) Rule() *Builder {
+	return b.blockOf("---", "")
+}
+
+// String returns the document as markdown.
+func (b *Builder) String() string {
+	return b.render(func(bl block) string { return bl.markdown })
+}
+
+// PlainText returns the document as plain text.
+func (b *Builder) PlainText() string {
+	return b.render(func(bl block) string { return bl.plain })
+}
+
+// Markup returns the document in the first of the given formats supported
+// by the builder, falling back to plain text.
+func (b *Builder) Markup(formats []domain.MarkupKind) domain.MarkupContent {
+	for _, format := range formats {
+		switch format {
+		case domain.MarkupKindMarkdown:
+			return domain.MarkupContent{Kind: format, Value: b.String()}
+		case domain.MarkupKindPlainText:
+			return domain.MarkupContent{Kind: format, Value: b.PlainText()}
+		}
+	}
+	return domain.MarkupContent{
+		Kind:  domain.MarkupKindPlainText,
+		Value: b.PlainText(),
+	}
+}
+
+// Hover returns the document as hover result in the format preferred by
+// the client.
+func (b *Builder) Hover(
+	caps domain.ClientCapabilities,
+	rng *domain.Range,
+) domain.HoverResult {
+	return domain.HoverResult{
+		Contents: b.Markup(caps.TextDocument.Hover.ContentFormat),
+		Range:    rng,
+	}
+}
+
+// Escape escapes the characters of text with a special meaning in markdown.
+func Escape(text string) string {
+	if !strings.ContainsAny(text, specialChars) {
+		return text
+	}
+	var escaped strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(specialChars, r) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// inlines appends an inline element to the current paragraph.
+func (b *Builder) inlines(markdown, plain string) *Builder {
+	b.inline.markdown.WriteString(markdown)
+	b.inline.plain.WriteString(plain)
+	return b
+}
+
+// blockOf ends the current paragraph and appends a block.
+func (b *Builder) blockOf(markdown, plain string) *Builder {
+	b.flush()
+	b.blocks = append(b.blocks, block{markdown: markdown, plain: plain})
+	return b
+}
+
+// flush ends the current paragraph.
+func (b *Builder) flush() {
+	if b.inline.markdown.Len() == 0 {
+		return
+	}
+	b.blocks = append(b.blocks, block{
+		markdown: b.inline.markdown.String(),
+		plain:    b.inline.plain.String(),
+	})
+	b.inline.markdown.Reset()
+	b.inline.plain.Reset()
+}
+
+// render joins the blocks and the current paragraph rendered by fn with
+// blank lines.
+func (b *Builder) render(fn func(block) string) string {
+	parts := make([]string, 0, len(b.blocks)+1)
+	for _, bl := range b.blocks {
+		if part := fn(bl); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	inline := block{
+		markdown: b.inline.markdown.String(),
+		plain:    b.inline.plain.String(),
+	}
+	if part := fn(inline); part != "" {
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// longestRun returns the length of the longest run of c in s.
+func longestRun(s string, c rune) int {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r != c {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return longest
+}
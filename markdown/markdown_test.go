@@ -0,0 +1,112 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(b *Builder)
+		markdown string
+		plain    string
+	}{
+		{
+			name:     "escapes text",
+			build:    func(b *Builder) { b.Text("a*b_c [d](e) #f") },
+			markdown: `a\*b\_c \[d\]\(e\) \#f`,
+			plain:    "a*b_c [d](e) #f",
+		},
+		{
+			name:     "inline elements share a paragraph",
+			build:    func(b *Builder) { b.Bold("x").Text(" and ").Italic("y") },
+			markdown: "**x** and _y_",
+			plain:    "x and y",
+		},
+		{
+			name:     "code with backticks",
+			build:    func(b *Builder) { b.Code("a``b").Text(" ").Code("`c") },
+			markdown: "```a``b``` `` `c ``",
+			plain:    "a``b `c",
+		},
+		{
+			name:     "link",
+			build:    func(b *Builder) { b.Link("docs", "https://x.dev/a b(c)") },
+			markdown: "[docs](https://x.dev/a%20b%28c%29)",
+			plain:    "docs (https://x.dev/a b(c))",
+		},
+		{
+			name:     "link without text",
+			build:    func(b *Builder) { b.Link("", "https://x.dev") },
+			markdown: "[](https://x.dev)",
+			plain:    "https://x.dev",
+		},
+		{
+			name: "blocks",
+			build: func(b *Builder) {
+				b.Heading(9, "Title").Text("intro").CodeBlock("go", "x := 1\n").Rule().Paragraph("end")
+			},
+			markdown: "###### Title\n\nintro\n\n```go\nx := 1\n```\n\n---\n\nend",
+			plain:    "Title\n\nintro\n\nx := 1\n\nend",
+		},
+		{
+			name:     "code block with a fence inside",
+			build:    func(b *Builder) { b.CodeBlock("", "````") },
+			markdown: "`````\n````\n`````",
+			plain:    "````",
+		},
+		{
+			name:     "list",
+			build:    func(b *Builder) { b.List("a_b", "c") },
+			markdown: "- a\\_b\n- c",
+			plain:    "- a_b\n- c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New()
+			tt.build(b)
+			if got := b.String(); got != tt.markdown {
+				t.Errorf("String() = %q, want %q", got, tt.markdown)
+			}
+			if got := b.PlainText(); got != tt.plain {
+				t.Errorf("PlainText() = %q, want %q", got, tt.plain)
+			}
+		})
+	}
+}
+
+func TestMarkup(t *testing.T) {
+	b := New().Bold("x")
+	tests := []struct {
+		name    string
+		formats []domain.MarkupKind
+		want    domain.MarkupContent
+	}{
+		{"no formats", nil, domain.MarkupContent{Kind: domain.MarkupKindPlainText, Value: "x"}},
+		{
+			"markdown preferred",
+			[]domain.MarkupKind{domain.MarkupKindMarkdown, domain.MarkupKindPlainText},
+			domain.MarkupContent{Kind: domain.MarkupKindMarkdown, Value: "**x**"},
+		},
+		{
+			"plain text preferred",
+			[]domain.MarkupKind{domain.MarkupKindPlainText, domain.MarkupKindMarkdown},
+			domain.MarkupContent{Kind: domain.MarkupKindPlainText, Value: "x"},
+		},
+		{
+			"unknown formats skipped",
+			[]domain.MarkupKind{"html", domain.MarkupKindMarkdown},
+			domain.MarkupContent{Kind: domain.MarkupKindMarkdown, Value: "**x**"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Markup(tt.formats); got != tt.want {
+				t.Errorf("Markup() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package glisp
+
+import (
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Locations returns the links as the result of a definition, declaration,
+// type definition or implementation request.
+//
+// The links are downgraded to plain locations if the client does not
+// support links for the method.
+func Locations(
+	caps domain.ClientCapabilities,
+	method domain.Method,
+	links []domain.LocationLink,
+) interface{} {
+	if LinkSupport(caps, method) {
+		if links == nil {
+			return []domain.LocationLink{}
+		}
+		return links
+	}
+	locations := make([]domain.Location, len(links))
+	for i, link := range links {
+		locations[i] = link.Location()
+	}
+	return locations
+}
+
+// LinkSupport reports whether the client supports location links as the
+// result of the definition, declaration, type definition or implementation
+// method.
+func LinkSupport(caps domain.ClientCapabilities, method domain.Method) bool {
+	switch method {
+	case domain.MethodRequestTextDocumentDeclaration:
+		return caps.TextDocument.Declaration.LinkSupport
+	case domain.MethodRequestTextDocumentDefinition:
+		return caps.TextDocument.Definition.LinkSupport
+	case domain.MethodRequestTextDocumentTypeDefinition:
+		return caps.TextDocument.TypeDefinition.LinkSupport
+	case domain.MethodRequestTextDocumentImplementation:
+		return caps.TextDocument.Implementation.LinkSupport
+	}
+	return false
+}
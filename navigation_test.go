@@ -0,0 +1,63 @@
+package glisp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestLocations(t *testing.T) {
+	links := []domain.LocationLink{{
+		OriginSelectionRange: &domain.Range{End: domain.Position{Character: 3}},
+		TargetURI:            "file:///a.go",
+		TargetRange:          span(2, 0, 20),
+		TargetSelectionRange: span(2, 5, 9),
+	}}
+	tests := []struct {
+		method  domain.Method
+		support func(caps *domain.ClientCapabilities)
+	}{
+		{domain.MethodRequestTextDocumentDeclaration, func(caps *domain.ClientCapabilities) {
+			caps.TextDocument.Declaration.LinkSupport = true
+		}},
+		{domain.MethodRequestTextDocumentDefinition, func(caps *domain.ClientCapabilities) {
+			caps.TextDocument.Definition.LinkSupport = true
+		}},
+		{domain.MethodRequestTextDocumentTypeDefinition, func(caps *domain.ClientCapabilities) {
+			caps.TextDocument.TypeDefinition.LinkSupport = true
+		}},
+		{domain.MethodRequestTextDocumentImplementation, func(caps *domain.ClientCapabilities) {
+			caps.TextDocument.Implementation.LinkSupport = true
+		}},
+	}
+	for i, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			var caps domain.ClientCapabilities
+			tt.support(&caps)
+			// other supports links for a different method only.
+			var other domain.ClientCapabilities
+			tests[(i+1)%len(tests)].support(&other)
+
+			got, ok := Locations(caps, tt.method, links).([]domain.LocationLink)
+			if !ok || len(got) != 1 || got[0] != links[0] {
+				t.Errorf("with link support = %#v, want the links", got)
+			}
+			locations, ok := Locations(other, tt.method, links).([]domain.Location)
+			want := domain.Location{URI: "file:///a.go", Range: span(2, 5, 9)}
+			if !ok || len(locations) != 1 || locations[0] != want {
+				t.Errorf("without link support = %#v, want %+v", locations, want)
+			}
+
+			for _, c := range []domain.ClientCapabilities{caps, other} {
+				data, err := json.Marshal(Locations(c, tt.method, nil))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(data) != "[]" {
+					t.Errorf("no links = %s, want []", data)
+				}
+			}
+		})
+	}
+}
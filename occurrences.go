@@ -0,0 +1,247 @@
+package glisp
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Occurrence is an occurrence of a symbol in a document.
+type Occurrence struct {
+	// Symbol identifies the symbol across the workspace.
+	Symbol string
+	// Range is the range of the occurrence, usually the name of the symbol.
+	Range domain.Range
+	// Definition marks the occurrence defining the symbol.
+	Definition bool
+	// Write marks an occurrence writing to the symbol, e.g. an assignment.
+	Write bool
+}
+
+// OccurrenceIndex indexes the occurrences of symbols in the documents of a
+// workspace.
+//
+// Servers register the definitions and uses of the symbols of a document
+// once after analyzing it and answer references, document highlight and
+// rename requests from the index.
+type OccurrenceIndex struct {
+	mu      sync.RWMutex
+	docs    map[string][]Occurrence
+	symbols map[string]map[string]bool
+}
+
+// NewOccurrenceIndex creates a new empty occurrence index.
+func NewOccurrenceIndex() *OccurrenceIndex {
+	return &OccurrenceIndex{
+		docs:    map[string][]Occurrence{},
+		symbols: map[string]map[string]bool{},
+	}
+}
+
+// SetDocument replaces the occurrences of the document at uri.
+func (x *OccurrenceIndex) SetDocument(uri string, occurrences []Occurrence) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeDocument(uri)
+	sorted := append([]Occurrence(nil), occurrences...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Compare(sorted[j].Range.Start) < 0
+	})
+	x.docs[uri] = sorted
+	for _, occurrence := range sorted {
+		uris, ok := x.symbols[occurrence.Symbol]
+		if !ok {
+			uris = map[string]bool{}
+			x.symbols[occurrence.Symbol] = uris
+		}
+		uris[uri] = true
+	}
+}
+
+// RemoveDocument removes the occurrences of the document at uri.
+func (x *OccurrenceIndex) RemoveDocument(uri string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.removeDocument(uri)
+}
+
+// OccurrenceAt returns the occurrence at the position in the document at
+// uri.
+func (x *OccurrenceIndex) OccurrenceAt(
+	uri string,
+	pos domain.Position,
+) (Occurrence, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.occurrenceAt(uri, pos)
+}
+
+// References answers a references request with the occurrences of the
+// symbol at the requested position in all documents.
+func (x *OccurrenceIndex) References(
+	params domain.ReferenceParams,
+) []domain.Location {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	locations := []domain.Location{}
+	at, ok := x.occurrenceAt(params.TextDocument.URI, params.Position)
+	if !ok {
+		return locations
+	}
+	x.eachOccurrence(at.Symbol, func(uri string, occurrence Occurrence) {
+		if occurrence.Definition && !params.Context.IncludeDeclaration {
+			return
+		}
+		locations = append(locations, domain.Location{
+			URI:   uri,
+			Range: occurrence.Range,
+		})
+	})
+	return locations
+}
+
+// Highlights answers a document highlight request with the occurrences of
+// the symbol at the requested position in the same document.
+//
+// Definitions and writing occurrences are highlighted as writes and all
+// others as reads.
+func (x *OccurrenceIndex) Highlights(
+	params domain.DocumentHighlightParams,
+) []domain.DocumentHighlight {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	highlights := []domain.DocumentHighlight{}
+	uri := params.TextDocument.URI
+	at, ok := x.occurrenceAt(uri, params.Position)
+	if !ok {
+		return highlights
+	}
+	for _, occurrence := range x.docs[uri] {
+		if occurrence.Symbol != at.Symbol {
+			continue
+		}
+		kind := domain.DocumentHighlightKindRead
+		if occurrence.Definition || occurrence.Write {
+			kind = domain.DocumentHighlightKindWrite
+		}
+		highlights = append(highlights, domain.DocumentHighlight{
+			Range: occurrence.Range,
+			Kind:  kind,
+		})
+	}
+	return highlights
+}
+
+// PrepareRename answers a prepare rename request with the range of the
+// occurrence at the requested position.
+//
+// nil is returned if there is no symbol to rename at the position.
+func (x *OccurrenceIndex) PrepareRename(
+	params domain.PrepareRenameParams,
+) *domain.Range {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	at, ok := x.occurrenceAt(params.TextDocument.URI, params.Position)
+	if !ok {
+		return nil
+	}
+	return &at.Range
+}
+
+// Rename returns the edit renaming all occurrences of the symbol at the
+// position in the document at uri to newName.
+//
+// The edit has one text document edit per document carrying the version
+// reported by version, e.g. DocumentStore.Version, so the client rejects
+// the edit if the document changed in the meantime. Versions are nil if
+// version is nil. Pass the edit through WorkspaceEdit to downgrade it for
+// clients without document changes support.
+//
+// ok is false if there is no symbol at the position.
+func (x *OccurrenceIndex) Rename(
+	uri string,
+	pos domain.Position,
+	newName string,
+	version func(uri string) *int,
+) (edit domain.WorkspaceEdit, ok bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	at, ok := x.occurrenceAt(uri, pos)
+	if !ok {
+		return domain.WorkspaceEdit{}, false
+	}
+	var current *domain.TextDocumentEdit
+	x.eachOccurrence(at.Symbol, func(uri string, occurrence Occurrence) {
+		if current == nil || current.TextDocument.URI != uri {
+			current = &domain.TextDocumentEdit{
+				TextDocument: domain.OptionalVersionedTextDocumentIdentifier{URI: uri},
+			}
+			if version != nil {
+				current.TextDocument.Version = version(uri)
+			}
+			edit.DocumentChanges = append(edit.DocumentChanges, domain.DocumentChange{
+				TextDocumentEdit: current,
+			})
+		}
+		current.Edits = append(current.Edits, domain.AnnotatedTextEdit{
+			TextEdit: domain.TextEdit{Range: occurrence.Range, NewText: newName},
+		})
+	})
+	return edit, true
+}
+
+// occurrenceAt returns the occurrence at the position in the document at
+// uri.
+//
+// x.mu must be held.
+func (x *OccurrenceIndex) occurrenceAt(
+	uri string,
+	pos domain.Position,
+) (Occurrence, bool) {
+	for _, occurrence := range x.docs[uri] {
+		if occurrence.Range.Start.Compare(pos) > 0 {
+			break
+		}
+		if occurrence.Range.Contains(pos) {
+			return occurrence, true
+		}
+	}
+	return Occurrence{}, false
+}
+
+// eachOccurrence calls fn for every occurrence of the symbol ordered by
+// document uri and position.
+//
+// x.mu must be held.
+func (x *OccurrenceIndex) eachOccurrence(
+	symbol string,
+	fn func(uri string, occurrence Occurrence),
+) {
+	uris := make([]string, 0, len(x.symbols[symbol]))
+	for uri := range x.symbols[symbol] {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		for _, occurrence := range x.docs[uri] {
+			if occurrence.Symbol == symbol {
+				fn(uri, occurrence)
+			}
+		}
+	}
+}
+
+// removeDocument removes the occurrences of the document at uri.
+//
+// x.mu must be held.
+func (x *OccurrenceIndex) removeDocument(uri string) {
+	for _, occurrence := range x.docs[uri] {
+		uris := x.symbols[occurrence.Symbol]
+		delete(uris, uri)
+		if len(uris) == 0 {
+			delete(x.symbols, occurrence.Symbol)
+		}
+	}
+	delete(x.docs, uri)
+}
@@ -0,0 +1,118 @@
+package glisp
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// span returns the range of the characters start to end on the line.
+func span(line, start, end int) domain.Range {
+	return domain.Range{
+		Start: domain.Position{Line: line, Character: start},
+		End:   domain.Position{Line: line, Character: end},
+	}
+}
+
+func newTestIndex() *OccurrenceIndex {
+	x := NewOccurrenceIndex()
+	x.SetDocument("file:///a.go", []Occurrence{
+		{Symbol: "x", Range: span(0, 4, 5), Definition: true},
+		{Symbol: "x", Range: span(1, 0, 1), Write: true},
+		{Symbol: "x", Range: span(2, 7, 8)},
+		{Symbol: "y", Range: span(2, 0, 1)},
+	})
+	x.SetDocument("file:///b.go", []Occurrence{
+		{Symbol: "x", Range: span(0, 0, 1)},
+	})
+	return x
+}
+
+func TestOccurrenceIndexHighlights(t *testing.T) {
+	var params domain.DocumentHighlightParams
+	params.TextDocument.URI = "file:///a.go"
+	params.Position = domain.Position{Line: 2, Character: 7}
+	highlights := newTestIndex().Highlights(params)
+	want := []domain.DocumentHighlightKind{
+		domain.DocumentHighlightKindWrite,
+		domain.DocumentHighlightKindWrite,
+		domain.DocumentHighlightKindRead,
+	}
+	if len(highlights) != len(want) {
+		t.Fatalf("got %d highlights, want %d", len(highlights), len(want))
+	}
+	for i, highlight := range highlights {
+		if highlight.Kind != want[i] {
+			t.Errorf("highlight %d at %s: kind = %v, want %v",
+				i, formatTestRange(highlight.Range), highlight.Kind, want[i])
+		}
+	}
+}
+
+func TestOccurrenceIndexReferences(t *testing.T) {
+	tests := []struct {
+		name               string
+		pos                domain.Position
+		includeDeclaration bool
+		want               int
+	}{
+		{"with declaration", domain.Position{Line: 2, Character: 7}, true, 4},
+		{"without declaration", domain.Position{Line: 2, Character: 7}, false, 3},
+		{"no symbol", domain.Position{Line: 3, Character: 0}, true, 0},
+	}
+	x := newTestIndex()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params domain.ReferenceParams
+			params.TextDocument.URI = "file:///a.go"
+			params.Position = tt.pos
+			params.Context.IncludeDeclaration = tt.includeDeclaration
+			if got := x.References(params); len(got) != tt.want {
+				t.Errorf("got %d references, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func formatTestRange(r domain.Range) string {
+	return r.Start.String() + "-" + r.End.String()
+}
+
+func TestOccurrenceIndexRename(t *testing.T) {
+	versions := map[string]int{"file:///a.go": 4}
+	version := func(uri string) *int {
+		if v, ok := versions[uri]; ok {
+			return &v
+		}
+		return nil
+	}
+	edit, ok := newTestIndex().Rename("file:///b.go", domain.Position{}, "z", version)
+	if !ok {
+		t.Fatal("no symbol at the position")
+	}
+	tests := []struct {
+		uri     string
+		version *int
+		edits   int
+	}{
+		{"file:///a.go", version("file:///a.go"), 3},
+		{"file:///b.go", nil, 1},
+	}
+	if len(edit.DocumentChanges) != len(tests) {
+		t.Fatalf("got %d document changes, want %d", len(edit.DocumentChanges), len(tests))
+	}
+	for i, tt := range tests {
+		change := edit.DocumentChanges[i].TextDocumentEdit
+		if change.TextDocument.URI != tt.uri || len(change.Edits) != tt.edits {
+			t.Errorf("change %d = %s with %d edits, want %s with %d",
+				i, change.TextDocument.URI, len(change.Edits), tt.uri, tt.edits)
+		}
+		if (change.TextDocument.Version == nil) != (tt.version == nil) ||
+			tt.version != nil && *change.TextDocument.Version != *tt.version {
+			t.Errorf("change %d: version = %v, want %v", i, change.TextDocument.Version, tt.version)
+		}
+	}
+	if _, ok := newTestIndex().Rename("file:///a.go", domain.Position{Line: 9}, "z", nil); ok {
+		t.Error("renamed without a symbol at the position")
+	}
+}
@@ -0,0 +1,131 @@
+package glisp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// DiagnosticsFunc computes the diagnostics of the document at uri.
+type DiagnosticsFunc func(ctx context.Context, uri string) ([]domain.Diagnostic, error)
+
+// PullDiagnostics answers pull diagnostic requests.
+//
+// Result ids are computed from the document versions so documents which
+// did not change since the previous pull are answered with unchanged
+// reports without computing their diagnostics. The generation of the result
+// ids is seeded per process so result ids of a previous server process
+// never match.
+type PullDiagnostics struct {
+	session    *Session
+	generation atomic.Uint64
+}
+
+// NewPullDiagnostics creates a new pull diagnostics helper refreshing the
+// client through the given session.
+func NewPullDiagnostics(session *Session) *PullDiagnostics {
+	p := &PullDiagnostics{session: session}
+	p.generation.Store(uint64(time.Now().UnixNano()))
+	return p
+}
+
+// ResultID returns the result id of a document with the given version.
+func (p *PullDiagnostics) ResultID(version int) string {
+	return fmt.Sprintf("%d:%d", p.generation.Load(), version)
+}
+
+// Invalidate invalidates all result ids handed out so far so the next pull
+// of every document computes its diagnostics again.
+func (p *PullDiagnostics) Invalidate() {
+	p.generation.Add(1)
+}
+
+// Refresh invalidates all result ids and asks the client to pull the
+// diagnostics again.
+func (p *PullDiagnostics) Refresh(ctx context.Context) error {
+	p.Invalidate()
+	return p.session.RefreshDiagnostics(ctx)
+}
+
+// Document answers a document diagnostic request for the document in the
+// given version.
+func (p *PullDiagnostics) Document(
+	ctx context.Context,
+	params domain.DocumentDiagnosticParams,
+	version int,
+	compute DiagnosticsFunc,
+) (domain.DocumentDiagnosticReport, error) {
+	return p.report(
+		ctx,
+		params.TextDocument.URI,
+		params.PreviousResultID,
+		&version,
+		compute,
+	)
+}
+
+// Workspace answers a workspace diagnostic request for the documents in
+// versions which maps the document uris to their current version.
+//
+// The version of a document which is not open in the client is nil. Its
+// report has a null version and its diagnostics are always computed since
+// it may have changed on disk.
+func (p *PullDiagnostics) Workspace(
+	ctx context.Context,
+	params domain.WorkspaceDiagnosticParams,
+	versions map[string]*int,
+	compute DiagnosticsFunc,
+) (domain.WorkspaceDiagnosticReport, error) {
+	previous := map[string]string{}
+	for _, id := range params.PreviousResultIDs {
+		previous[id.URI] = id.Value
+	}
+	uris := make([]string, 0, len(versions))
+	for uri := range versions {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	report := domain.WorkspaceDiagnosticReport{
+		Items: make([]domain.WorkspaceDocumentDiagnosticReport, 0, len(uris)),
+	}
+	for _, uri := range uris {
+		version := versions[uri]
+		item, err := p.report(ctx, uri, previous[uri], version, compute)
+		if err != nil {
+			return domain.WorkspaceDiagnosticReport{}, err
+		}
+		report.Items = append(report.Items, domain.WorkspaceDocumentDiagnosticReport{
+			DocumentDiagnosticReport: item,
+			URI:                      uri,
+			Version:                  version,
+		})
+	}
+	return report, nil
+}
+
+// report creates the diagnostic report of the document at uri.
+//
+// A document without a version is never reported as unchanged.
+func (p *PullDiagnostics) report(
+	ctx context.Context,
+	uri, previousResultID string,
+	version *int,
+	compute DiagnosticsFunc,
+) (domain.DocumentDiagnosticReport, error) {
+	resultID := fmt.Sprintf("%d:-", p.generation.Load())
+	if version != nil {
+		resultID = p.ResultID(*version)
+	}
+	if version != nil && previousResultID == resultID {
+		return domain.NewUnchangedDocumentDiagnosticReport(resultID), nil
+	}
+	diagnostics, err := compute(ctx, uri)
+	if err != nil {
+		return domain.DocumentDiagnosticReport{}, err
+	}
+	return domain.NewFullDocumentDiagnosticReport(resultID, diagnostics), nil
+}
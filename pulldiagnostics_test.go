@@ -0,0 +1,108 @@
+package glisp
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestPullDiagnosticsDocument(t *testing.T) {
+	const uri = "file:///a.go"
+	p := NewPullDiagnostics(NewSession(io.Discard))
+	calls := 0
+	compute := func(context.Context, string) ([]domain.Diagnostic, error) {
+		calls++
+		return []domain.Diagnostic{{Message: "x"}}, nil
+	}
+	pull := func(previous string, version int) domain.DocumentDiagnosticReport {
+		t.Helper()
+		params := domain.DocumentDiagnosticParams{PreviousResultID: previous}
+		params.TextDocument.URI = uri
+		report, err := p.Document(context.Background(), params, version, compute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+
+	first := pull("", 1)
+	tests := []struct {
+		name     string
+		previous string
+		version  int
+		want     domain.DocumentDiagnosticReportKind
+	}{
+		{"same version", first.ResultID, 1, domain.DocumentDiagnosticReportKindUnchanged},
+		{"new version", first.ResultID, 2, domain.DocumentDiagnosticReportKindFull},
+		{"previous process", "0:1", 1, domain.DocumentDiagnosticReportKindFull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pull(tt.previous, tt.version).Kind; got != tt.want {
+				t.Errorf("kind = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	p.Invalidate()
+	if got := pull(first.ResultID, 1).Kind; got != domain.DocumentDiagnosticReportKindFull {
+		t.Errorf("kind after invalidate = %v, want full", got)
+	}
+	if calls != 4 {
+		t.Errorf("computed %d times, want 4", calls)
+	}
+}
+
+func TestPullDiagnosticsWorkspace(t *testing.T) {
+	p := NewPullDiagnostics(NewSession(io.Discard))
+	compute := func(context.Context, string) ([]domain.Diagnostic, error) {
+		return nil, nil
+	}
+	version := 1
+	versions := map[string]*int{
+		"file:///closed.go": nil,
+		"file:///open.go":   &version,
+	}
+	first, err := p.Workspace(
+		context.Background(),
+		domain.WorkspaceDiagnosticParams{},
+		versions,
+		compute,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params domain.WorkspaceDiagnosticParams
+	for _, item := range first.Items {
+		params.PreviousResultIDs = append(params.PreviousResultIDs, domain.PreviousResultID{
+			URI:   item.URI,
+			Value: item.ResultID,
+		})
+	}
+	second, err := p.Workspace(context.Background(), params, versions, compute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		uri     string
+		kind    domain.DocumentDiagnosticReportKind
+		version *int
+	}{
+		{"file:///closed.go", domain.DocumentDiagnosticReportKindFull, nil},
+		{"file:///open.go", domain.DocumentDiagnosticReportKindUnchanged, &version},
+	}
+	if len(second.Items) != len(tests) {
+		t.Fatalf("got %d items, want %d", len(second.Items), len(tests))
+	}
+	for i, tt := range tests {
+		item := second.Items[i]
+		if item.URI != tt.uri || item.Kind != tt.kind {
+			t.Errorf("item %d = %s %v, want %s %v", i, item.URI, item.Kind, tt.uri, tt.kind)
+		}
+		if (item.Version == nil) != (tt.version == nil) {
+			t.Errorf("item %d: version = %v, want %v", i, item.Version, tt.version)
+		}
+	}
+}
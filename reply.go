@@ -0,0 +1,51 @@
+package glisp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Reply writes the response to the request with the given id to w framed
+// with the base protocol header.
+//
+// A non-nil err is sent as the error of the response. Errors which are not
+// a *domain.Error are sent with the CodeInternalError code.
+func Reply(w ResponseWriter, id int, result interface{}, err error) error {
+	response := domain.Response{
+		RPC:    "2.0",
+		ID:     id,
+		Result: result,
+	}
+	if err != nil {
+		var rpcErr *domain.Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &domain.Error{
+				Code:    domain.CodeInternalError,
+				Message: err.Error(),
+			}
+		}
+		response.Result = nil
+		response.Error = rpcErr
+	}
+	return writeMessage(w, response)
+}
+
+// writeMessage encodes the message, frames it with the base protocol
+// header and writes it to w.
+//
+// Every message sent to the client goes through writeMessage. The frame is
+// written in a single write so writers serializing writes never interleave
+// the header and content of different messages.
+func writeMessage(w io.Writer, msg interface{}) error {
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	frame := fmt.Appendf(nil, "Content-Length: %d\r\n\r\n", len(content))
+	_, err = w.Write(append(frame, content...))
+	return err
+}
@@ -0,0 +1,48 @@
+package glisp
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestReply(t *testing.T) {
+	tests := []struct {
+		name   string
+		result interface{}
+		err    error
+		want   string
+	}{
+		{
+			name:   "result",
+			result: []int{1},
+			want:   `{"jsonrpc":"2.0","id":7,"result":[1]}`,
+		},
+		{
+			name: "rpc error",
+			err:  &domain.Error{Code: domain.CodeInvalidParams, Message: "bad"},
+			want: `{"jsonrpc":"2.0","id":7,"error":{"code":-32602,"message":"bad"}}`,
+		},
+		{
+			name:   "internal error",
+			result: "dropped",
+			err:    errors.New("boom"),
+			want:   `{"jsonrpc":"2.0","id":7,"error":{"code":-32603,"message":"boom"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Reply(&buf, 7, tt.result, tt.err); err != nil {
+				t.Fatal(err)
+			}
+			want := "Content-Length: " + strconv.Itoa(len(tt.want)) + "\r\n\r\n" + tt.want
+			if buf.String() != want {
+				t.Errorf("Reply wrote\n%q\nwant\n%q", buf.String(), want)
+			}
+		})
+	}
+}
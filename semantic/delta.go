@@ -0,0 +1,101 @@
+package semantic
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Cache remembers the last semantic tokens sent for every document to
+// answer full delta requests with the changes since the previous result.
+type Cache struct {
+	mu     sync.Mutex
+	nextID int
+	docs   map[string]cached
+}
+
+// cached are the last tokens sent for a document.
+type cached struct {
+	resultID string
+	data     []uint32
+}
+
+// NewCache creates a new empty semantic tokens cache.
+func NewCache() *Cache {
+	return &Cache{docs: map[string]cached{}}
+}
+
+// Full answers a full semantic tokens request with the encoded tokens of
+// the document at uri and remembers them under a new result id.
+func (c *Cache) Full(uri string, data []uint32) domain.SemanticTokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return domain.SemanticTokens{ResultID: c.store(uri, data), Data: data}
+}
+
+// Delta answers a full delta semantic tokens request with the changes of
+// the encoded tokens of the document since the previous result.
+//
+// The result is a domain.SemanticTokensDelta if the previous result is
+// still known and the full domain.SemanticTokens otherwise.
+func (c *Cache) Delta(
+	params domain.SemanticTokensDeltaParams,
+	data []uint32,
+) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	uri := params.TextDocument.URI
+	prev, ok := c.docs[uri]
+	resultID := c.store(uri, data)
+	if !ok || prev.resultID != params.PreviousResultID {
+		return domain.SemanticTokens{ResultID: resultID, Data: data}
+	}
+	return domain.SemanticTokensDelta{
+		ResultID: resultID,
+		Edits:    Edits(prev.data, data),
+	}
+}
+
+// Remove forgets the tokens of the document at uri, e.g. once it is
+// closed.
+func (c *Cache) Remove(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.docs, uri)
+}
+
+// store remembers the tokens of the document and returns their new result
+// id.
+//
+// c.mu must be held.
+func (c *Cache) store(uri string, data []uint32) string {
+	c.nextID++
+	resultID := strconv.Itoa(c.nextID)
+	c.docs[uri] = cached{resultID: resultID, data: data}
+	return resultID
+}
+
+// Edits returns the edits turning the encoded tokens prev into next.
+//
+// Common leading and trailing integers are kept so a single edit replaces
+// the part in between. No edits are returned if both are equal.
+func Edits(prev, next []uint32) []domain.SemanticTokensEdit {
+	start := 0
+	for start < len(prev) && start < len(next) && prev[start] == next[start] {
+		start++
+	}
+	if start == len(prev) && start == len(next) {
+		return []domain.SemanticTokensEdit{}
+	}
+	end := 0
+	for end < len(prev)-start && end < len(next)-start &&
+		prev[len(prev)-1-end] == next[len(next)-1-end] {
+		end++
+	}
+	return []domain.SemanticTokensEdit{{
+		Start:       uint32(start),
+		DeleteCount: uint32(len(prev) - start - end),
+		Data:        next[start : len(next)-end],
+	}}
+}
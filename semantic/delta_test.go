@@ -0,0 +1,103 @@
+package semantic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// applyEdits applies semantic token edits to data like a client does.
+func applyEdits(data []uint32, edits []domain.SemanticTokensEdit) []uint32 {
+	result := append([]uint32(nil), data...)
+	for i := len(edits) - 1; i >= 0; i-- {
+		edit := edits[i]
+		tail := append([]uint32(nil), result[edit.Start+edit.DeleteCount:]...)
+		result = append(append(result[:edit.Start], edit.Data...), tail...)
+	}
+	return result
+}
+
+// equalData reports whether a and b hold the same integers.
+func equalData(a, b []uint32) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+func TestEdits(t *testing.T) {
+	tests := []struct {
+		name string
+		prev []uint32
+		next []uint32
+		want []domain.SemanticTokensEdit
+	}{
+		{"equal", []uint32{1, 2, 3}, []uint32{1, 2, 3}, []domain.SemanticTokensEdit{}},
+		{"both empty", nil, nil, []domain.SemanticTokensEdit{}},
+		{
+			"replace middle",
+			[]uint32{1, 2, 3, 4}, []uint32{1, 9, 4},
+			[]domain.SemanticTokensEdit{{Start: 1, DeleteCount: 2, Data: []uint32{9}}},
+		},
+		{
+			"append",
+			[]uint32{1, 2}, []uint32{1, 2, 3},
+			[]domain.SemanticTokensEdit{{Start: 2, DeleteCount: 0, Data: []uint32{3}}},
+		},
+		{
+			"delete prefix",
+			[]uint32{1, 2, 3}, []uint32{3},
+			[]domain.SemanticTokensEdit{{Start: 0, DeleteCount: 2, Data: []uint32{}}},
+		},
+		{
+			"repeated values",
+			[]uint32{1, 1, 1}, []uint32{1, 1},
+			[]domain.SemanticTokensEdit{{Start: 2, DeleteCount: 1, Data: []uint32{}}},
+		},
+		{
+			"from empty",
+			nil, []uint32{0, 1, 2, 3, 0},
+			[]domain.SemanticTokensEdit{{Start: 0, DeleteCount: 0, Data: []uint32{0, 1, 2, 3, 0}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Edits(tt.prev, tt.next)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Edits = %+v, want %+v", got, tt.want)
+			}
+			if applied := applyEdits(tt.prev, got); !equalData(applied, tt.next) {
+				t.Errorf("applied edits = %v, want %v", applied, tt.next)
+			}
+		})
+	}
+}
+
+func TestCacheDelta(t *testing.T) {
+	const uri = "file:///a.go"
+	c := NewCache()
+	first := c.Full(uri, []uint32{0, 0, 1, 0, 0})
+	delta := func(previous string, data []uint32) interface{} {
+		params := domain.SemanticTokensDeltaParams{PreviousResultID: previous}
+		params.TextDocument.URI = uri
+		return c.Delta(params, data)
+	}
+
+	got, ok := delta(first.ResultID, []uint32{0, 0, 2, 0, 0}).(domain.SemanticTokensDelta)
+	if !ok {
+		t.Fatal("delta from the previous result is not a delta")
+	}
+	want := []domain.SemanticTokensEdit{{Start: 2, DeleteCount: 1, Data: []uint32{2}}}
+	if !reflect.DeepEqual(got.Edits, want) {
+		t.Errorf("edits = %+v, want %+v", got.Edits, want)
+	}
+	if got.ResultID == first.ResultID {
+		t.Error("delta reused the previous result id")
+	}
+
+	if _, ok := delta(first.ResultID, nil).(domain.SemanticTokens); !ok {
+		t.Error("delta from an outdated result is not a full result")
+	}
+	c.Remove(uri)
+	if _, ok := delta(got.ResultID, nil).(domain.SemanticTokens); !ok {
+		t.Error("delta after remove is not a full result")
+	}
+}
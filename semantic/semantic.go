@@ -0,0 +1,234 @@
+// Package semantic builds the semantic tokens of documents for semantic
+// highlighting.
+//
+// Servers declare the token types and modifiers they use once in a Legend,
+// add tokens with absolute ranges to a Builder and let a Cache answer full
+// and delta requests with the relative integer encoding of the protocol.
+package semantic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Legend maps the names of token types and modifiers to the integers of
+// the encoding.
+type Legend struct {
+	types     []string
+	modifiers []string
+	typeIndex map[string]int
+	modIndex  map[string]int
+}
+
+// NewLegend creates a legend with the given token types and modifiers.
+//
+// At most 32 modifiers are supported as they are encoded as bit flags.
+func NewLegend(types, modifiers []string) (*Legend, error) {
+	if len(modifiers) > 32 {
+		return nil, fmt.Errorf("too many token modifiers: %d", len(modifiers))
+	}
+	l := &Legend{
+		types:     append([]string(nil), types...),
+		modifiers: append([]string(nil), modifiers...),
+		typeIndex: map[string]int{},
+		modIndex:  map[string]int{},
+	}
+	for i, typ := range types {
+		if _, ok := l.typeIndex[typ]; ok {
+			return nil, fmt.Errorf("duplicate token type %q", typ)
+		}
+		l.typeIndex[typ] = i
+	}
+	for i, modifier := range modifiers {
+		if _, ok := l.modIndex[modifier]; ok {
+			return nil, fmt.Errorf("duplicate token modifier %q", modifier)
+		}
+		l.modIndex[modifier] = i
+	}
+	return l, nil
+}
+
+// StandardLegend returns a legend with all token types and modifiers
+// predefined by the specification.
+func StandardLegend() *Legend {
+	l, _ := NewLegend([]string{
+		domain.SemanticTokenNamespace,
+		domain.SemanticTokenType,
+		domain.SemanticTokenClass,
+		domain.SemanticTokenEnum,
+		domain.SemanticTokenInterface,
+		domain.SemanticTokenStruct,
+		domain.SemanticTokenTypeParameter,
+		domain.SemanticTokenParameter,
+		domain.SemanticTokenVariable,
+		domain.SemanticTokenProperty,
+		domain.SemanticTokenEnumMember,
+		domain.SemanticTokenEvent,
+		domain.SemanticTokenFunction,
+		domain.SemanticTokenMethod,
+		domain.SemanticTokenMacro,
+		domain.SemanticTokenKeyword,
+		domain.SemanticTokenModifier,
+		domain.SemanticTokenComment,
+		domain.SemanticTokenString,
+		domain.SemanticTokenNumber,
+		domain.SemanticTokenRegexp,
+		domain.SemanticTokenOperator,
+		domain.SemanticTokenDecorator,
+	}, []string{
+		domain.SemanticTokenModifierDeclaration,
+		domain.SemanticTokenModifierDefinition,
+		domain.SemanticTokenModifierReadonly,
+		domain.SemanticTokenModifierStatic,
+		domain.SemanticTokenModifierDeprecated,
+		domain.SemanticTokenModifierAbstract,
+		domain.SemanticTokenModifierAsync,
+		domain.SemanticTokenModifierModification,
+		domain.SemanticTokenModifierDocumentation,
+		domain.SemanticTokenModifierDefaultLibrary,
+	})
+	return l
+}
+
+// Legend returns the legend as sent in the server capabilities.
+func (l *Legend) Legend() domain.SemanticTokensLegend {
+	return domain.SemanticTokensLegend{
+		TokenTypes:     append([]string{}, l.types...),
+		TokenModifiers: append([]string{}, l.modifiers...),
+	}
+}
+
+// Options returns the semantic tokens server capabilities advertising the
+// legend, full requests and optionally range and delta requests.
+func (l *Legend) Options(rangeRequests, delta bool) *domain.SemanticTokensOptions {
+	return &domain.SemanticTokensOptions{
+		Legend: l.Legend(),
+		Range:  rangeRequests,
+		Full:   &domain.SemanticTokensFullOptions{Delta: delta},
+	}
+}
+
+// token is a semantic token with an absolute position.
+type token struct {
+	line, start, length int
+	typ                 int
+	modifiers           uint32
+}
+
+// Builder collects the semantic tokens of a document and encodes them.
+type Builder struct {
+	legend *Legend
+	lines  *domain.LineIndex
+	tokens []token
+}
+
+// NewBuilder creates a builder for the tokens of text using the legend.
+//
+// The characters of the ranges of the tokens are counted in the encoding.
+func NewBuilder(
+	legend *Legend,
+	text string,
+	enc domain.PositionEncodingKind,
+) *Builder {
+	return &Builder{legend: legend, lines: domain.NewLineIndex(text, enc)}
+}
+
+// Add adds a token of the given type and modifiers covering the range.
+//
+// Ranges spanning multiple lines are split into a token per line as not
+// every client supports multiline tokens. An error is returned for types
+// and modifiers missing from the legend.
+func (b *Builder) Add(rng domain.Range, typ string, modifiers ...string) error {
+	index, ok := b.legend.typeIndex[typ]
+	if !ok {
+		return fmt.Errorf("unknown token type %q", typ)
+	}
+	var bits uint32
+	for _, modifier := range modifiers {
+		bit, ok := b.legend.modIndex[modifier]
+		if !ok {
+			return fmt.Errorf("unknown token modifier %q", modifier)
+		}
+		bits |= 1 << bit
+	}
+	for line := rng.Start.Line; line <= rng.End.Line; line++ {
+		start, end := 0, b.lines.LineLen(line)
+		if line == rng.Start.Line {
+			start = rng.Start.Character
+		}
+		if line == rng.End.Line {
+			end = rng.End.Character
+		}
+		if end <= start {
+			continue
+		}
+		b.tokens = append(b.tokens, token{
+			line:      line,
+			start:     start,
+			length:    end - start,
+			typ:       index,
+			modifiers: bits,
+		})
+	}
+	return nil
+}
+
+// Encode returns the tokens in the relative integer encoding of the
+// protocol ordered by position.
+func (b *Builder) Encode() []uint32 {
+	return encode(b.sorted(), nil)
+}
+
+// EncodeRange returns the encoded tokens overlapping the range.
+func (b *Builder) EncodeRange(rng domain.Range) []uint32 {
+	return encode(b.sorted(), &rng)
+}
+
+// sorted returns the tokens ordered by position.
+func (b *Builder) sorted() []token {
+	sort.SliceStable(b.tokens, func(i, j int) bool {
+		if b.tokens[i].line != b.tokens[j].line {
+			return b.tokens[i].line < b.tokens[j].line
+		}
+		return b.tokens[i].start < b.tokens[j].start
+	})
+	return b.tokens
+}
+
+// encode encodes the sorted tokens overlapping rng or all tokens if rng is
+// nil.
+//
+// Every token is encoded as its line relative to the previous token, its
+// start relative to the previous token on the same line, its length, its
+// type and its modifiers.
+func encode(tokens []token, rng *domain.Range) []uint32 {
+	data := make([]uint32, 0, 5*len(tokens))
+	prevLine, prevStart := 0, 0
+	for _, t := range tokens {
+		if rng != nil && !overlaps(t, *rng) {
+			continue
+		}
+		deltaStart := t.start
+		if t.line == prevLine {
+			deltaStart -= prevStart
+		}
+		data = append(data,
+			uint32(t.line-prevLine),
+			uint32(deltaStart),
+			uint32(t.length),
+			uint32(t.typ),
+			t.modifiers,
+		)
+		prevLine, prevStart = t.line, t.start
+	}
+	return data
+}
+
+// overlaps reports whether the token overlaps the range.
+func overlaps(t token, rng domain.Range) bool {
+	start := domain.Position{Line: t.line, Character: t.start}
+	end := domain.Position{Line: t.line, Character: t.start + t.length}
+	return start.Compare(rng.End) < 0 && rng.Start.Compare(end) < 0
+}
@@ -0,0 +1,134 @@
+package semantic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func span(startLine, startChar, endLine, endChar int) domain.Range {
+	return domain.Range{
+		Start: domain.Position{Line: startLine, Character: startChar},
+		End:   domain.Position{Line: endLine, Character: endChar},
+	}
+}
+
+func TestNewLegend(t *testing.T) {
+	many := make([]string, 33)
+	for i := range many {
+		many[i] = string(rune('a' + i))
+	}
+	tests := []struct {
+		name      string
+		types     []string
+		modifiers []string
+		wantErr   bool
+	}{
+		{"valid", []string{"a", "b"}, []string{"x"}, false},
+		{"duplicate type", []string{"a", "a"}, nil, true},
+		{"duplicate modifier", nil, []string{"x", "x"}, true},
+		{"too many modifiers", nil, many, true},
+		{"32 modifiers", nil, many[:32], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLegend(tt.types, tt.modifiers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	legend, err := NewLegend(
+		[]string{"variable", "function", "comment"},
+		[]string{"declaration", "readonly"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const text = "func main() {\n\tx := 1\n}\n/* a\nb */"
+	type add struct {
+		rng       domain.Range
+		typ       string
+		modifiers []string
+	}
+	tests := []struct {
+		name    string
+		adds    []add
+		rng     *domain.Range
+		want    []uint32
+		wantErr bool
+	}{
+		{
+			name: "relative encoding",
+			adds: []add{
+				{span(0, 5, 0, 9), "function", []string{"declaration"}},
+				{span(1, 1, 1, 2), "variable", []string{"declaration", "readonly"}},
+				{span(0, 0, 0, 4), "comment", nil},
+			},
+			want: []uint32{
+				0, 0, 4, 2, 0,
+				0, 5, 4, 1, 1,
+				1, 1, 1, 0, 3,
+			},
+		},
+		{
+			name: "multiline tokens are split",
+			adds: []add{{span(3, 0, 4, 4), "comment", nil}},
+			want: []uint32{
+				3, 0, 4, 2, 0,
+				1, 0, 4, 2, 0,
+			},
+		},
+		{
+			name: "empty lines are skipped",
+			adds: []add{{span(2, 1, 3, 0), "comment", nil}},
+			want: []uint32{},
+		},
+		{
+			name: "range",
+			adds: []add{
+				{span(0, 5, 0, 9), "function", nil},
+				{span(1, 1, 1, 2), "variable", nil},
+			},
+			rng:  func() *domain.Range { r := span(1, 0, 2, 0); return &r }(),
+			want: []uint32{1, 1, 1, 0, 0},
+		},
+		{
+			name:    "unknown type",
+			adds:    []add{{span(0, 0, 0, 1), "keyword", nil}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown modifier",
+			adds:    []add{{span(0, 0, 0, 1), "variable", []string{"static"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(legend, text, domain.PositionEncodingUTF16)
+			for _, a := range tt.adds {
+				if err := b.Add(a.rng, a.typ, a.modifiers...); err != nil {
+					if !tt.wantErr {
+						t.Fatal(err)
+					}
+					return
+				}
+			}
+			if tt.wantErr {
+				t.Fatal("want an error")
+			}
+			got := b.Encode()
+			if tt.rng != nil {
+				got = b.EncodeRange(*tt.rng)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encoded %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
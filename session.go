@@ -0,0 +1,317 @@
+package glisp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// ErrUnsupported is returned when a request is not supported by the
+// capabilities of the client.
+var ErrUnsupported = errors.New("glisp: not supported by the client")
+
+// Notifier sends notifications from the server to the client.
+type Notifier interface {
+	Notify(ctx context.Context, method domain.Method, params interface{}) error
+}
+
+// Caller sends requests from the server to the client.
+type Caller interface {
+	Call(
+		ctx context.Context,
+		method domain.Method,
+		params, result interface{},
+	) error
+}
+
+// Session is the server side of a connection to a language client.
+//
+// It frames outgoing messages with the base protocol header and serializes
+// writes so it can be shared between handlers.
+type Session struct {
+	writeMu sync.Mutex
+	w       io.Writer
+
+	mu           sync.Mutex
+	nextID       int
+	pending      map[int]chan callResult
+	capabilities domain.ClientCapabilities
+	encoding     domain.PositionEncodingKind
+}
+
+// cancelMethod is the method of the notification cancelling a request.
+var cancelMethod = domain.CancelRequestMethod
+
+// cancelParams are the params of a $/cancelRequest notification for a
+// request of the server which always has an integer id.
+type cancelParams struct {
+	ID int `json:"id"`
+}
+
+// callResult is the response of the client to a request of the server.
+type callResult struct {
+	result json.RawMessage
+	err    *domain.Error
+}
+
+// NewSession creates a new session writing messages to w.
+func NewSession(w io.Writer) *Session {
+	return &Session{
+		w:       w,
+		pending: map[int]chan callResult{},
+	}
+}
+
+// SetClientCapabilities stores the capabilities the client sent in its
+// initialize request.
+func (s *Session) SetClientCapabilities(caps domain.ClientCapabilities) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capabilities = caps
+}
+
+// ClientCapabilities returns the capabilities of the client.
+func (s *Session) ClientCapabilities() domain.ClientCapabilities {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capabilities
+}
+
+// NegotiatePositionEncoding picks the first of the position encodings
+// preferred by the server which the client supports and uses it for the
+// rest of the session.
+//
+// The result is advertised as the position encoding of the server
+// capabilities.
+func (s *Session) NegotiatePositionEncoding(
+	preferred ...domain.PositionEncodingKind,
+) domain.PositionEncodingKind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.encoding = domain.NegotiatePositionEncoding(s.capabilities, preferred...)
+	return s.encoding
+}
+
+// PositionEncoding returns the negotiated position encoding, UTF-16 if
+// none was negotiated.
+func (s *Session) PositionEncoding() domain.PositionEncodingKind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.encoding == "" {
+		return domain.PositionEncodingUTF16
+	}
+	return s.encoding
+}
+
+// Notify sends a notification with the given method and params to the
+// client.
+func (s *Session) Notify(
+	ctx context.Context,
+	method domain.Method,
+	params interface{},
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.write(domain.Message[interface{}]{
+		RPC:    "2.0",
+		Method: &method,
+		Params: params,
+	})
+}
+
+// Call sends a request with the given method and params to the client and
+// waits for its response which is decoded into result.
+//
+// Responses of the client are delivered through HandleResponse.
+func (s *Session) Call(
+	ctx context.Context,
+	method domain.Method,
+	params, result interface{},
+) error {
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	done := make(chan callResult, 1)
+	s.pending[id] = done
+	s.mu.Unlock()
+	err := s.write(domain.Message[interface{}]{
+		RPC:    "2.0",
+		ID:     &id,
+		Method: &method,
+		Params: params,
+	})
+	if err != nil {
+		s.forget(id)
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		// Forget the request before cancelling it so a response racing
+		// the cancellation is reported as unknown by HandleResponse
+		// instead of being delivered to a call nobody waits for.
+		s.forget(id)
+		// Tell the client the response is no longer needed, e.g. to close
+		// a message the user did not answer. The cancellation is best
+		// effort so its error is ignored.
+		_ = s.write(domain.Message[interface{}]{
+			RPC:    "2.0",
+			Method: &cancelMethod,
+			Params: cancelParams{ID: id},
+		})
+		return ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return res.err
+		}
+		if result == nil || len(res.result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(res.result, result)
+	}
+}
+
+// forget removes the pending request with the given id.
+func (s *Session) forget(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, id)
+}
+
+// HandleResponse delivers the response of the client to the request of the
+// server with the given id.
+//
+// It reports whether a request with the id was waiting for a response. A
+// response to a request which was cancelled or already answered is
+// dropped.
+func (s *Session) HandleResponse(
+	id int,
+	result json.RawMessage,
+	rpcErr *domain.Error,
+) bool {
+	s.mu.Lock()
+	done, ok := s.pending[id]
+	delete(s.pending, id)
+	s.mu.Unlock()
+	if ok {
+		done <- callResult{result: result, err: rpcErr}
+	}
+	return ok
+}
+
+// RefreshDiagnostics asks the client to refresh all pulled diagnostics.
+func (s *Session) RefreshDiagnostics(ctx context.Context) error {
+	if !s.ClientCapabilities().Workspace.Diagnostics.RefreshSupport {
+		return ErrUnsupported
+	}
+	return s.Call(ctx, domain.MethodWorkspaceDiagnosticRefresh, nil, nil)
+}
+
+// RefreshCodeLenses asks the client to refresh the code lenses of all
+// documents.
+func (s *Session) RefreshCodeLenses(ctx context.Context) error {
+	if !s.ClientCapabilities().Workspace.CodeLens.RefreshSupport {
+		return ErrUnsupported
+	}
+	return s.Call(ctx, domain.MethodWorkspaceCodeLensRefresh, nil, nil)
+}
+
+// RefreshSemanticTokens asks the client to refresh the semantic tokens of
+// all documents.
+func (s *Session) RefreshSemanticTokens(ctx context.Context) error {
+	if !s.ClientCapabilities().Workspace.SemanticTokens.RefreshSupport {
+		return ErrUnsupported
+	}
+	return s.Call(ctx, domain.MethodWorkspaceSemanticTokensRefresh, nil, nil)
+}
+
+// RefreshInlayHints asks the client to refresh the inlay hints of all
+// documents, e.g. after a background analysis finished.
+func (s *Session) RefreshInlayHints(ctx context.Context) error {
+	if !s.ClientCapabilities().Workspace.InlayHint.RefreshSupport {
+		return ErrUnsupported
+	}
+	return s.Call(ctx, domain.MethodWorkspaceInlayHintRefresh, nil, nil)
+}
+
+// ShowMessage asks the client to show a message to the user.
+func (s *Session) ShowMessage(
+	ctx context.Context,
+	typ domain.MessageType,
+	message string,
+) error {
+	return s.Notify(ctx, domain.MethodWindowShowMessage, domain.ShowMessageParams{
+		Type:    typ,
+		Message: message,
+	})
+}
+
+// LogMessage asks the client to log a message.
+func (s *Session) LogMessage(
+	ctx context.Context,
+	typ domain.MessageType,
+	message string,
+) error {
+	return s.Notify(ctx, domain.MethodWindowLogMessage, domain.LogMessageParams{
+		Type:    typ,
+		Message: message,
+	})
+}
+
+// ShowMessageRequest shows a message with actions to the user and waits
+// for the chosen action.
+//
+// The action is nil if the user dismissed the message. If ctx is done
+// before the user answers, the request is cancelled and ctx.Err() is
+// returned.
+//
+// Unlike ShowDocument no capability is checked: every client must support
+// the request and its window.showMessage capability only describes
+// additional properties of action items, which MessageActionItem does not
+// have.
+func (s *Session) ShowMessageRequest(
+	ctx context.Context,
+	typ domain.MessageType,
+	message string,
+	actions ...domain.MessageActionItem,
+) (*domain.MessageActionItem, error) {
+	var chosen *domain.MessageActionItem
+	err := s.Call(ctx, domain.MethodWindowShowMessageRequest, domain.ShowMessageRequestParams{
+		Type:    typ,
+		Message: message,
+		Actions: actions,
+	}, &chosen)
+	if err != nil {
+		return nil, err
+	}
+	return chosen, nil
+}
+
+// ShowDocument asks the client to show a resource and reports whether it
+// was shown.
+func (s *Session) ShowDocument(
+	ctx context.Context,
+	params domain.ShowDocumentParams,
+) (bool, error) {
+	support := s.ClientCapabilities().Window.ShowDocument
+	if support == nil || !support.Support {
+		return false, ErrUnsupported
+	}
+	var result domain.ShowDocumentResult
+	err := s.Call(ctx, domain.MethodWindowShowDocument, params, &result)
+	if err != nil {
+		return false, err
+	}
+	return result.Success, nil
+}
+
+// write encodes the message and writes it to the client.
+func (s *Session) write(msg interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return writeMessage(s.w, msg)
+}
@@ -0,0 +1,140 @@
+package glisp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// lockedBuffer is a buffer the session and the test can use concurrently.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// waitFor waits until the session wrote a message containing s.
+func waitFor(t *testing.T, b *lockedBuffer, s string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(b.String(), s) {
+		if time.Now().After(deadline) {
+			t.Fatalf("session wrote %q, want a message containing %q", b.String(), s)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSessionCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		respond  func(s *Session)
+		cancel   bool
+		want     *domain.MessageActionItem
+		wantErr  error
+		canceled bool
+	}{
+		{
+			name: "result",
+			respond: func(s *Session) {
+				s.HandleResponse(1, json.RawMessage(`{"title":"Retry"}`), nil)
+			},
+			want: &domain.MessageActionItem{Title: "Retry"},
+		},
+		{
+			name: "dismissed",
+			respond: func(s *Session) {
+				s.HandleResponse(1, json.RawMessage(`null`), nil)
+			},
+		},
+		{
+			name: "error",
+			respond: func(s *Session) {
+				s.HandleResponse(1, nil, &domain.Error{Code: domain.CodeRequestFailed})
+			},
+			wantErr: &domain.Error{Code: domain.CodeRequestFailed},
+		},
+		{
+			name:     "cancelled",
+			cancel:   true,
+			wantErr:  context.Canceled,
+			canceled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out lockedBuffer
+			s := NewSession(&out)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			type answer struct {
+				action *domain.MessageActionItem
+				err    error
+			}
+			answered := make(chan answer, 1)
+			go func() {
+				action, err := s.ShowMessageRequest(ctx, domain.MessageType(1), "failed",
+					domain.MessageActionItem{Title: "Retry"})
+				answered <- answer{action, err}
+			}()
+			waitFor(t, &out, `"method":"window/showMessageRequest"`)
+			if tt.cancel {
+				cancel()
+			} else {
+				tt.respond(s)
+			}
+			got := <-answered
+			if !sameError(got.err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", got.err, tt.wantErr)
+			}
+			if (got.action == nil) != (tt.want == nil) ||
+				got.action != nil && *got.action != *tt.want {
+				t.Errorf("action = %+v, want %+v", got.action, tt.want)
+			}
+			if tt.canceled {
+				waitFor(t, &out, `"params":{"id":1},"method":"$/cancelRequest"`)
+			}
+			if s.HandleResponse(1, json.RawMessage(`null`), nil) {
+				t.Error("late response was delivered to a finished call")
+			}
+		})
+	}
+}
+
+// sameError reports whether err is or reads like want.
+func sameError(err, want error) bool {
+	if err == nil || want == nil {
+		return err == want
+	}
+	return errors.Is(err, want) || err.Error() == want.Error()
+}
+
+func TestSessionShowDocumentUnsupported(t *testing.T) {
+	var out lockedBuffer
+	s := NewSession(&out)
+	_, err := s.ShowDocument(context.Background(), domain.ShowDocumentParams{URI: "file:///a.go"})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("err = %v, want ErrUnsupported", err)
+	}
+	if out.String() != "" {
+		t.Errorf("session wrote %q for an unsupported request", out.String())
+	}
+}
@@ -0,0 +1,148 @@
+package glisp
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Call describes the call expression enclosing a cursor position.
+type Call struct {
+	// Name is the identifier directly before the opening parenthesis of
+	// the call, e.g. `fmt.Println`. It is empty for anonymous calls.
+	Name string
+	// Open is the byte offset of the opening parenthesis of the call.
+	Open int
+	// ActiveParameter is the index of the parameter at the position
+	// computed from the commas between the opening parenthesis and the
+	// position.
+	ActiveParameter int
+}
+
+// EnclosingCall returns the innermost call expression enclosing the byte
+// offset in text.
+//
+// Commas nested in parentheses, brackets, braces and string literals of
+// the arguments are not counted, including the unterminated string literal
+// being typed at the offset. ok is false if the offset is not inside the
+// arguments of a call.
+func EnclosingCall(text string, offset int) (call Call, ok bool) {
+	offset = min(max(offset, 0), len(text))
+	if start, ok := openString(text, offset); ok {
+		offset = start
+	}
+	commas, depth := 0, 0
+	for i := offset - 1; i >= 0; i-- {
+		switch c := text[i]; c {
+		case ')', ']', '}':
+			depth++
+		case '[', '{':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			// The offset is inside a literal which is one of the arguments.
+			commas = 0
+		case '(':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			return Call{
+				Name:            identifierBefore(text, i),
+				Open:            i,
+				ActiveParameter: commas,
+			}, true
+		case ',':
+			if depth == 0 {
+				commas++
+			}
+		case ';':
+			if depth == 0 {
+				return Call{}, false
+			}
+		case '"', '\'', '`':
+			i = stringStart(text, i, c)
+		}
+	}
+	return Call{}, false
+}
+
+// ActiveParameter returns the call expression enclosing the position in
+// text.
+func ActiveParameter(
+	text string,
+	pos domain.Position,
+	enc domain.PositionEncodingKind,
+) (Call, bool) {
+	offset, err := domain.OffsetAt(text, pos, enc)
+	if err != nil {
+		return Call{}, false
+	}
+	return EnclosingCall(text, offset)
+}
+
+// openString returns the offset of the quote opening the string literal
+// which is still open at the byte offset in text.
+//
+// Interpreted string and rune literals end at the end of their line like
+// in stringStart, raw string literals may span lines.
+func openString(text string, offset int) (start int, ok bool) {
+	var quote byte
+	for i := 0; i < offset; i++ {
+		c := text[i]
+		switch {
+		case quote == 0:
+			if c == '"' || c == '\'' || c == '`' {
+				quote, start = c, i
+			}
+		case c == '\\' && quote != '`':
+			i++
+		case c == quote, c == '\n' && quote != '`':
+			quote = 0
+		}
+	}
+	return start, quote != 0
+}
+
+// stringStart returns the offset of the quote opening the string literal
+// closed by the quote at end.
+func stringStart(text string, end int, quote byte) int {
+	for i := end - 1; i >= 0; i-- {
+		if text[i] == '\n' && quote != '`' {
+			// Unterminated or not a string literal, e.g. an apostrophe in
+			// a comment.
+			return end
+		}
+		if text[i] != quote {
+			continue
+		}
+		escapes := 0
+		for j := i - 1; j >= 0 && text[j] == '\\'; j-- {
+			escapes++
+		}
+		if escapes%2 == 0 {
+			return i
+		}
+	}
+	return end
+}
+
+// identifierBefore returns the dotted identifier ending right before the
+// byte offset end ignoring whitespace.
+func identifierBefore(text string, end int) string {
+	for end > 0 && (text[end-1] == ' ' || text[end-1] == '\t') {
+		end--
+	}
+	start := end
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) &&
+			r != '_' && r != '$' && r != '.' {
+			break
+		}
+		start -= size
+	}
+	return text[start:end]
+}
@@ -0,0 +1,68 @@
+package glisp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestEnclosingCall(t *testing.T) {
+	tests := []struct {
+		name string
+		// text marks the offset with a `|`.
+		text   string
+		ok     bool
+		fn     string
+		active int
+	}{
+		{"first argument", "fmt.Println(|", true, "fmt.Println", 0},
+		{"second argument", "f(a, |)", true, "f", 1},
+		{"nested call", "f(a, g(b, |))", true, "g", 1},
+		{"after nested call", "f(a, g(b, c), |)", true, "f", 2},
+		{"commas in literals", "f([]int{1, 2}, \"x,y\", |)", true, "f", 2},
+		{"comma in rune literal", "f(',', |)", true, "f", 1},
+		{"unterminated string", "f(a, \"x, y|", true, "f", 1},
+		{"unterminated raw string", "f(`x,\ny, |", true, "f", 0},
+		{"escaped quote in string", "f(\"a\\\", b\", |)", true, "f", 1},
+		{"anonymous call", "(|)", true, "", 0},
+		{"outside of call", "f(a); |", false, "", 0},
+		{"after statement", "x := 1; |", false, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := strings.Index(tt.text, "|")
+			text := tt.text[:offset] + tt.text[offset+1:]
+			call, ok := EnclosingCall(text, offset)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if call.Name != tt.fn || call.ActiveParameter != tt.active {
+				t.Errorf("call = %q with active parameter %d, want %q with %d",
+					call.Name, call.ActiveParameter, tt.fn, tt.active)
+			}
+		})
+	}
+}
+
+func TestActiveParameter(t *testing.T) {
+	text := "f(a,\n  b)"
+	tests := []struct {
+		pos    domain.Position
+		ok     bool
+		active int
+	}{
+		{domain.Position{Line: 0, Character: 2}, true, 0},
+		{domain.Position{Line: 1, Character: 2}, true, 1},
+		// Characters beyond the end of the line are clamped.
+		{domain.Position{Line: 0, Character: 99}, true, 1},
+		{domain.Position{Line: 5, Character: 0}, false, 0},
+	}
+	for _, tt := range tests {
+		call, ok := ActiveParameter(text, tt.pos, domain.PositionEncodingUTF16)
+		if ok != tt.ok || call.ActiveParameter != tt.active {
+			t.Errorf("ActiveParameter(%s) = %d, %v, want %d, %v",
+				tt.pos, call.ActiveParameter, ok, tt.active, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package snippet builds correctly escaped snippet strings for completion
+// items and downgrades them to plain text for clients without snippet
+// support.
+//
+// Microsoft LSP Docs:
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/#snippet_syntax
+package snippet
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// Builder builds a snippet string and its plain text equivalent.
+//
+// The zero value is an empty snippet ready to use.
+type Builder struct {
+	snippet strings.Builder
+	plain   strings.Builder
+}
+
+// New creates a new empty snippet builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Text appends literal text to the snippet escaping the characters with a
+// special meaning in the snippet syntax.
+func (b *Builder) Text(text string) *Builder {
+	b.snippet.WriteString(escape(text, `\$}`))
+	b.plain.WriteString(text)
+	return b
+}
+
+// Tabstop appends the tabstop n, e.g. ${1}.
+//
+// The braces keep a digit appended next from extending the number of the
+// tabstop.
+func (b *Builder) Tabstop(n int) *Builder {
+	b.snippet.WriteString("${" + strconv.Itoa(n) + "}")
+	return b
+}
+
+// Final appends the final cursor position ${0}.
+func (b *Builder) Final() *Builder {
+	return b.Tabstop(0)
+}
+
+// Placeholder appends the tabstop n with the default text, e.g. ${1:name}.
+func (b *Builder) Placeholder(n int, text string) *Builder {
+	return b.PlaceholderFunc(n, func(nested *Builder) {
+		nested.Text(text)
+	})
+}
+
+// PlaceholderFunc appends the tabstop n with a default built by fn which
+// may contain nested tabstops and placeholders.
+func (b *Builder) PlaceholderFunc(n int, fn func(*Builder)) *Builder {
+	nested := New()
+	fn(nested)
+	b.snippet.WriteString("${" + strconv.Itoa(n) + ":" + nested.String() + "}")
+	b.plain.WriteString(nested.PlainText())
+	return b
+}
+
+// Choice appends the tabstop n offering the given choices, e.g.
+// ${1|one,two|}.
+//
+// The plain text of a choice is its first option.
+func (b *Builder) Choice(n int, choices ...string) *Builder {
+	if len(choices) == 0 {
+		return b.Tabstop(n)
+	}
+	escaped := make([]string, len(choices))
+	for i, choice := range choices {
+		escaped[i] = escape(choice, `\,|`)
+	}
+	b.snippet.WriteString(
+		"${" + strconv.Itoa(n) + "|" + strings.Join(escaped, ",") + "|}",
+	)
+	b.plain.WriteString(choices[0])
+	return b
+}
+
+// Variable appends the variable with the given name and default text, e.g.
+// ${TM_FILENAME:file}.
+//
+// The plain text of a variable is its default.
+func (b *Builder) Variable(name, text string) *Builder {
+	if text == "" {
+		b.snippet.WriteString("${" + name + "}")
+		return b
+	}
+	b.snippet.WriteString("${" + name + ":" + escape(text, `\$}`) + "}")
+	b.plain.WriteString(text)
+	return b
+}
+
+// String returns the snippet in the snippet syntax.
+func (b *Builder) String() string {
+	return b.snippet.String()
+}
+
+// PlainText returns the snippet as plain text with the placeholders
+// replaced by their defaults and the tabstops removed.
+func (b *Builder) PlainText() string {
+	return b.plain.String()
+}
+
+// Supported reports whether the client supports snippets in completion
+// items.
+func Supported(caps domain.ClientCapabilities) bool {
+	return caps.TextDocument.Completion.CompletionItem.SnippetSupport
+}
+
+// Apply sets the text inserted by the completion item to the snippet.
+//
+// The new text of the text edit of the item is set if it has one and its
+// insert text otherwise. Clients without snippet support get the plain text
+// of the snippet.
+func Apply(
+	item *domain.CompletionItem,
+	b *Builder,
+	caps domain.ClientCapabilities,
+) {
+	text, format := b.PlainText(), domain.InsertTextFormatPlainText
+	if Supported(caps) {
+		text, format = b.String(), domain.InsertTextFormatSnippet
+	}
+	switch {
+	case item.InsertReplaceEdit != nil:
+		item.InsertReplaceEdit.NewText = text
+	case item.TextEdit != nil:
+		item.TextEdit.NewText = text
+	default:
+		item.InsertText = text
+	}
+	item.InsertTextFormat = format
+}
+
+// escape escapes the characters in chars in text with a backslash.
+func escape(text, chars string) string {
+	if !strings.ContainsAny(text, chars) {
+		return text
+	}
+	var escaped strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(chars, r) {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
@@ -0,0 +1,150 @@
+package snippet
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(b *Builder)
+		snippet string
+		plain   string
+	}{
+		{
+			name:    "escapes text",
+			build:   func(b *Builder) { b.Text(`a $b } \c {d}`) },
+			snippet: `a \$b \} \\c {d\}`,
+			plain:   `a $b } \c {d}`,
+		},
+		{
+			name: "tabstops",
+			build: func(b *Builder) {
+				b.Text("f(").Tabstop(1).Text(")").Final()
+			},
+			snippet: "f(${1})${0}",
+			plain:   "f()",
+		},
+		{
+			name:    "tabstop before a digit",
+			build:   func(b *Builder) { b.Tabstop(1).Text("0") },
+			snippet: "${1}0",
+			plain:   "0",
+		},
+		{
+			name:    "final before a digit",
+			build:   func(b *Builder) { b.Final().Text("1") },
+			snippet: "${0}1",
+			plain:   "1",
+		},
+		{
+			name:    "placeholder",
+			build:   func(b *Builder) { b.Placeholder(1, "na}me") },
+			snippet: `${1:na\}me}`,
+			plain:   "na}me",
+		},
+		{
+			name: "nested placeholder",
+			build: func(b *Builder) {
+				b.PlaceholderFunc(1, func(nested *Builder) {
+					nested.Text("x, ").Placeholder(2, "y")
+				})
+			},
+			snippet: "${1:x, ${2:y}}",
+			plain:   "x, y",
+		},
+		{
+			name:    "choice",
+			build:   func(b *Builder) { b.Choice(1, "a,b", "c|d", `e\f`, "$g") },
+			snippet: `${1|a\,b,c\|d,e\\f,$g|}`,
+			plain:   "a,b",
+		},
+		{
+			name:    "empty choice",
+			build:   func(b *Builder) { b.Choice(2) },
+			snippet: "${2}",
+		},
+		{
+			name:    "variable",
+			build:   func(b *Builder) { b.Variable("TM_FILENAME", "file$") },
+			snippet: `${TM_FILENAME:file\$}`,
+			plain:   "file$",
+		},
+		{
+			name:    "variable without default",
+			build:   func(b *Builder) { b.Variable("TM_SELECTED_TEXT", "") },
+			snippet: "${TM_SELECTED_TEXT}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New()
+			tt.build(b)
+			if got := b.String(); got != tt.snippet {
+				t.Errorf("String() = %q, want %q", got, tt.snippet)
+			}
+			if got := b.PlainText(); got != tt.plain {
+				t.Errorf("PlainText() = %q, want %q", got, tt.plain)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	var snippets, plain domain.ClientCapabilities
+	snippets.TextDocument.Completion.CompletionItem.SnippetSupport = true
+	b := New().Text("f(").Placeholder(1, "x").Text(")")
+	tests := []struct {
+		name   string
+		item   domain.CompletionItem
+		caps   domain.ClientCapabilities
+		text   func(item domain.CompletionItem) string
+		want   string
+		format domain.InsertTextFormat
+	}{
+		{
+			name:   "insert text",
+			caps:   snippets,
+			text:   func(item domain.CompletionItem) string { return item.InsertText },
+			want:   "f(${1:x})",
+			format: domain.InsertTextFormatSnippet,
+		},
+		{
+			name:   "plain text without support",
+			caps:   plain,
+			text:   func(item domain.CompletionItem) string { return item.InsertText },
+			want:   "f(x)",
+			format: domain.InsertTextFormatPlainText,
+		},
+		{
+			name:   "text edit",
+			item:   domain.CompletionItem{TextEdit: &domain.TextEdit{}},
+			caps:   snippets,
+			text:   func(item domain.CompletionItem) string { return item.TextEdit.NewText },
+			want:   "f(${1:x})",
+			format: domain.InsertTextFormatSnippet,
+		},
+		{
+			name:   "insert replace edit",
+			item:   domain.CompletionItem{InsertReplaceEdit: &domain.InsertReplaceEdit{}},
+			caps:   plain,
+			text:   func(item domain.CompletionItem) string { return item.InsertReplaceEdit.NewText },
+			want:   "f(x)",
+			format: domain.InsertTextFormatPlainText,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			Apply(&item, b, tt.caps)
+			if got := tt.text(item); got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+			if item.InsertTextFormat != tt.format {
+				t.Errorf("format = %v, want %v", item.InsertTextFormat, tt.format)
+			}
+		})
+	}
+}
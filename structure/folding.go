@@ -0,0 +1,175 @@
+package structure
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// FoldingRanges returns the folding ranges of brackets, comments and
+// indentation of text ordered by start line.
+//
+// Bracket and comment ranges win over indentation ranges starting on the
+// same line.
+func FoldingRanges(text string, syntax Syntax) []domain.FoldingRange {
+	ranges := append(Brackets(text, syntax), Comments(text, syntax)...)
+	taken := map[int]bool{}
+	for _, r := range ranges {
+		taken[r.StartLine] = true
+	}
+	for _, r := range Indentation(text, syntax.TabSize) {
+		if !taken[r.StartLine] {
+			ranges = append(ranges, r)
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].StartLine != ranges[j].StartLine {
+			return ranges[i].StartLine < ranges[j].StartLine
+		}
+		return ranges[i].EndLine > ranges[j].EndLine
+	})
+	return ranges
+}
+
+// Brackets returns a folding range for every bracket pair spanning
+// multiple lines.
+//
+// The ranges end on the line before the closing bracket so it stays
+// visible when the range is folded. Only the outermost pair is folded if
+// several open on the same line.
+func Brackets(text string, syntax Syntax) []domain.FoldingRange {
+	pairs, _ := scan(text, syntax)
+	lines := domain.NewLineIndex(text, domain.PositionEncodingUTF8)
+	ranges := []domain.FoldingRange{}
+	folded := map[int]bool{}
+	for _, p := range pairs {
+		start := lines.PositionAt(p.open).Line
+		end := lines.PositionAt(p.close).Line - 1
+		if end <= start || folded[start] {
+			continue
+		}
+		folded[start] = true
+		ranges = append(ranges, domain.FoldingRange{StartLine: start, EndLine: end})
+	}
+	return ranges
+}
+
+// Comments returns a folding range for every block comment spanning
+// multiple lines and every run of consecutive lines holding only a line
+// comment.
+func Comments(text string, syntax Syntax) []domain.FoldingRange {
+	_, comments := scan(text, syntax)
+	lines := domain.NewLineIndex(text, domain.PositionEncodingUTF8)
+	ranges := []domain.FoldingRange{}
+	runStart, runEnd := -1, -1
+	flush := func() {
+		if runEnd > runStart {
+			ranges = append(ranges, domain.FoldingRange{
+				StartLine: runStart,
+				EndLine:   runEnd,
+				Kind:      domain.FoldingRangeKindComment,
+			})
+		}
+		runStart, runEnd = -1, -1
+	}
+	for _, c := range comments {
+		start := lines.PositionAt(c.start)
+		if !c.line {
+			flush()
+			end := lines.PositionAt(c.end).Line
+			if end > start.Line {
+				ranges = append(ranges, domain.FoldingRange{
+					StartLine: start.Line,
+					EndLine:   end,
+					Kind:      domain.FoldingRangeKindComment,
+				})
+			}
+			continue
+		}
+		lineStart := c.start - start.Character
+		if strings.TrimSpace(text[lineStart:c.start]) != "" {
+			// A trailing comment after code.
+			flush()
+			continue
+		}
+		if runEnd >= 0 && start.Line == runEnd+1 {
+			runEnd = start.Line
+			continue
+		}
+		flush()
+		runStart, runEnd = start.Line, start.Line
+	}
+	flush()
+	return ranges
+}
+
+// Indentation returns a folding range for every line followed by lines
+// indented deeper than itself.
+//
+// Blank lines do not end a range but are not included at its end. Tabs
+// count as tabSize columns, 4 if tabSize is not positive.
+func Indentation(text string, tabSize int) []domain.FoldingRange {
+	if tabSize <= 0 {
+		tabSize = 4
+	}
+	indents := lineIndents(text, tabSize)
+	ranges := []domain.FoldingRange{}
+	// open holds the lines whose ranges are still open, innermost last.
+	var open []int
+	last := -1
+	closeTo := func(indent int) {
+		for len(open) > 0 && indents[open[len(open)-1]] >= indent {
+			start := open[len(open)-1]
+			open = open[:len(open)-1]
+			if last > start {
+				ranges = append(ranges, domain.FoldingRange{StartLine: start, EndLine: last})
+			}
+		}
+	}
+	for line, indent := range indents {
+		if indent < 0 {
+			continue
+		}
+		closeTo(indent)
+		open = append(open, line)
+		last = line
+	}
+	closeTo(0)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].StartLine < ranges[j].StartLine
+	})
+	return ranges
+}
+
+// lineIndents returns the indentation width of every line of text or -1
+// for blank lines.
+func lineIndents(text string, tabSize int) []int {
+	var indents []int
+	indent, blank := 0, true
+	for i := 0; i <= len(text); i++ {
+		if i == len(text) || text[i] == '\n' || text[i] == '\r' {
+			if blank {
+				indent = -1
+			}
+			indents = append(indents, indent)
+			if i+1 < len(text) && text[i] == '\r' && text[i+1] == '\n' {
+				i++
+			}
+			indent, blank = 0, true
+			continue
+		}
+		if !blank {
+			continue
+		}
+		switch text[i] {
+		case ' ':
+			indent++
+		case '\t':
+			indent += tabSize - indent%tabSize
+		default:
+			blank = false
+		}
+	}
+	return indents
+}
@@ -0,0 +1,116 @@
+package structure
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// formatRanges formats folding ranges as `start-end` with their kind.
+func formatRanges(ranges []domain.FoldingRange) []string {
+	formatted := make([]string, len(ranges))
+	for i, r := range ranges {
+		formatted[i] = fmt.Sprintf("%d-%d", r.StartLine, r.EndLine)
+		if r.Kind != "" {
+			formatted[i] += " " + string(r.Kind)
+		}
+	}
+	return formatted
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBrackets(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"single line", "f(a, {b})", []string{}},
+		{"block", "func f() {\n\tx()\n}", []string{"0-1"}},
+		{"nested", "a {\n\tb {\n\t\tc\n\t}\n}", []string{"0-3", "1-2"}},
+		{"outermost on the same line", "f({\n\tx\n})", []string{"0-1"}},
+		{"ignores strings", "s := \"{\"\n{\n\tx\n}", []string{"1-2"}},
+		{"ignores comments", "// {\n{\n\tx\n}\n/* } */", []string{"1-2"}},
+		{"mismatched closer", "{\n(\n]\n}", []string{"0-2"}},
+		{"escaped quote", "\"\\\"{\"\n{\nx\n}", []string{"1-2"}},
+		{"raw string across lines", "`{\n`\n{\nx\n}", []string{"2-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatRanges(Brackets(tt.text, DefaultSyntax))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("Brackets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"single line comment", "// a\nx", []string{}},
+		{"line comment run", "// a\n// b\n  // c\nx", []string{"0-2 comment"}},
+		{"trailing comment ends a run", "// a\n// b\nx // c\n// d", []string{"0-1 comment"}},
+		{"blank line ends a run", "// a\n// b\n\n// c\n// d", []string{"0-1 comment", "3-4 comment"}},
+		{"block comment", "/* a\n b\n*/", []string{"0-2 comment"}},
+		{"single line block comment", "/* a */", []string{}},
+		{"unterminated block comment", "/* a\nb", []string{"0-1 comment"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatRanges(Comments(tt.text, DefaultSyntax))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("Comments = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndentation(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		tabSize int
+		want    []string
+	}{
+		{"flat", "a\nb\nc", 4, []string{}},
+		{"nested", "a:\n  b:\n    c\n  d\ne", 4, []string{"0-3", "1-2"}},
+		{"blank lines inside but not at the end", "a:\n  b\n\n  c\n\nd", 4, []string{"0-3"}},
+		{"tabs and spaces", "a:\n\tb\n    c\nd", 4, []string{"0-2"}},
+		{"default tab size", "a:\n\tb", 0, []string{"0-1"}},
+		{"crlf", "a:\r\n  b\r\nc", 4, []string{"0-1"}},
+		{"open at the end", "a:\n  b\n  c\n", 4, []string{"0-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatRanges(Indentation(tt.text, tt.tabSize))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("Indentation = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFoldingRanges(t *testing.T) {
+	const text = "// a\n// b\nfunc f() {\n\tif x {\n\t\ty()\n\t}\n}\nlist:\n  - a\n  - b"
+	want := []string{"0-1 comment", "2-5", "3-4", "7-9"}
+	got := formatRanges(FoldingRanges(text, DefaultSyntax))
+	if !equalStrings(got, want) {
+		t.Errorf("FoldingRanges = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+package structure
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// SelectionRanges returns the selection ranges around the positions of
+// text in the same order.
+//
+// Every selection starts at the word at the position, expands to the
+// content and then to the brackets of every enclosing bracket pair and
+// ends with the whole text. An error is returned for positions outside of
+// text.
+func SelectionRanges(
+	text string,
+	positions []domain.Position,
+	syntax Syntax,
+	enc domain.PositionEncodingKind,
+) ([]domain.SelectionRange, error) {
+	pairs, _ := scan(text, syntax)
+	lines := domain.NewLineIndex(text, enc)
+	selections := make([]domain.SelectionRange, len(positions))
+	for i, pos := range positions {
+		offset, err := domain.OffsetAt(text, pos, enc)
+		if err != nil {
+			return nil, err
+		}
+		spans := expansions(text, pairs, offset)
+		var parent *domain.SelectionRange
+		for j := len(spans) - 1; j >= 0; j-- {
+			parent = &domain.SelectionRange{
+				Range:  lines.Range(spans[j][0], spans[j][1]),
+				Parent: parent,
+			}
+		}
+		selections[i] = *parent
+	}
+	return selections, nil
+}
+
+// expansions returns the byte ranges the selection at offset expands to,
+// innermost first and without duplicates.
+//
+// Every range contains the previous one as the protocol requires, so the
+// pair of a bracket right after the word at offset is skipped.
+func expansions(text string, pairs []pair, offset int) [][2]int {
+	var spans [][2]int
+	add := func(start, end int) {
+		if n := len(spans); n > 0 {
+			last := spans[n-1]
+			if last == [2]int{start, end} || start > last[0] || end < last[1] {
+				return
+			}
+		}
+		spans = append(spans, [2]int{start, end})
+	}
+	if start, end := word(text, offset); end > start {
+		add(start, end)
+	}
+	for i := len(pairs) - 1; i >= 0; i-- {
+		p := pairs[i]
+		if p.open > offset || offset > p.close {
+			continue
+		}
+		if p.open < offset {
+			add(p.open+1, p.close)
+		}
+		add(p.open, p.close+1)
+	}
+	add(0, len(text))
+	return spans
+}
+
+// word returns the byte range of the identifier around offset.
+func word(text string, offset int) (start, end int) {
+	start, end = offset, offset
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:start])
+		if !isWord(r) {
+			break
+		}
+		start -= size
+	}
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if !isWord(r) {
+			break
+		}
+		end += size
+	}
+	return start, end
+}
+
+// isWord reports whether r is part of an identifier.
+func isWord(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,71 @@
+package structure
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestSelectionRanges(t *testing.T) {
+	const text = "f(a, g(bar))\nx"
+	tests := []struct {
+		name string
+		pos  domain.Position
+		// want are the selected texts from the innermost out.
+		want []string
+	}{
+		{
+			name: "word in nested call",
+			pos:  domain.Position{Line: 0, Character: 8},
+			want: []string{"bar", "(bar)", "a, g(bar)", "(a, g(bar))", text},
+		},
+		{
+			name: "word before an opening bracket",
+			pos:  domain.Position{Line: 0, Character: 6},
+			want: []string{"g", "a, g(bar)", "(a, g(bar))", text},
+		},
+		{
+			name: "before a closing bracket",
+			pos:  domain.Position{Line: 0, Character: 10},
+			want: []string{"bar", "(bar)", "a, g(bar)", "(a, g(bar))", text},
+		},
+		{
+			name: "between closing brackets",
+			pos:  domain.Position{Line: 0, Character: 11},
+			want: []string{"a, g(bar)", "(a, g(bar))", text},
+		},
+		{
+			name: "outside brackets",
+			pos:  domain.Position{Line: 1, Character: 1},
+			want: []string{"x", text},
+		},
+		{
+			name: "whitespace",
+			pos:  domain.Position{Line: 0, Character: 4},
+			want: []string{"a, g(bar)", "(a, g(bar))", text},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selections, err := SelectionRanges(text, []domain.Position{tt.pos}, DefaultSyntax, domain.PositionEncodingUTF16)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for s := &selections[0]; s != nil; s = s.Parent {
+				start, err := domain.OffsetAt(text, s.Range.Start, domain.PositionEncodingUTF16)
+				if err != nil {
+					t.Fatal(err)
+				}
+				end, err := domain.OffsetAt(text, s.Range.End, domain.PositionEncodingUTF16)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, text[start:end])
+			}
+			if !equalStrings(got, tt.want) {
+				t.Errorf("selections = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
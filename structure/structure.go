@@ -0,0 +1,130 @@
+// Package structure computes folding and selection ranges from the
+// lexical structure of a document: its indentation, bracket pairs,
+// comments and string literals.
+//
+// It works for any language described by a Syntax and needs no parser, so
+// servers get reasonable defaults before implementing language specific
+// providers.
+package structure
+
+import (
+	"sort"
+	"strings"
+)
+
+// Syntax describes the lexical structure of a language.
+type Syntax struct {
+	// Brackets lists the bracket pairs as consecutive opening and closing
+	// characters, e.g. `()[]{}`.
+	Brackets string
+	// LineComment starts a comment running to the end of the line, e.g.
+	// `//`. It is empty if the language has none.
+	LineComment string
+	// BlockComment are the delimiters of block comments, e.g. `/*` and
+	// `*/`. They are empty if the language has none.
+	BlockComment [2]string
+	// Quotes are the characters delimiting string literals. Literals
+	// quoted with a backtick may span lines.
+	Quotes string
+	// TabSize is the width of a tab when computing indentation. It
+	// defaults to 4.
+	TabSize int
+}
+
+// DefaultSyntax describes C-like languages.
+var DefaultSyntax = Syntax{
+	Brackets:     "()[]{}",
+	LineComment:  "//",
+	BlockComment: [2]string{"/*", "*/"},
+	Quotes:       "\"'`",
+	TabSize:      4,
+}
+
+// pair is a matched pair of brackets given by their byte offsets.
+type pair struct {
+	open, close int
+}
+
+// comment is a comment given by its byte offsets.
+type comment struct {
+	start, end int
+	line       bool
+}
+
+// scan returns the matched bracket pairs ordered by their opening bracket
+// and the comments of text ordered by position.
+//
+// Brackets inside comments and string literals are ignored. A closing
+// bracket not matching the innermost open bracket closes the innermost
+// open bracket of its kind if there is one.
+func scan(text string, syntax Syntax) (pairs []pair, comments []comment) {
+	var stack []int
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case syntax.LineComment != "" &&
+			strings.HasPrefix(text[i:], syntax.LineComment):
+			end := strings.IndexAny(text[i:], "\r\n")
+			if end < 0 {
+				end = len(text) - i
+			}
+			comments = append(comments, comment{start: i, end: i + end, line: true})
+			i += end - 1
+		case syntax.BlockComment[0] != "" &&
+			strings.HasPrefix(text[i:], syntax.BlockComment[0]):
+			start := i + len(syntax.BlockComment[0])
+			end := strings.Index(text[start:], syntax.BlockComment[1])
+			if end < 0 {
+				end = len(text)
+			} else {
+				end = start + end + len(syntax.BlockComment[1])
+			}
+			comments = append(comments, comment{start: i, end: end})
+			i = end - 1
+		case strings.IndexByte(syntax.Quotes, c) >= 0:
+			i = stringEnd(text, i)
+		default:
+			k := strings.IndexByte(syntax.Brackets, c)
+			if k < 0 {
+				continue
+			}
+			if k%2 == 0 {
+				stack = append(stack, i)
+				continue
+			}
+			open := syntax.Brackets[k-1]
+			for j := len(stack) - 1; j >= 0; j-- {
+				if text[stack[j]] == open {
+					pairs = append(pairs, pair{open: stack[j], close: i})
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].open < pairs[j].open
+	})
+	return pairs, comments
+}
+
+// stringEnd returns the offset of the quote closing the string literal
+// opened at start or the end of the line for unterminated literals.
+func stringEnd(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case '\n', '\r':
+			if quote != '`' {
+				return i - 1
+			}
+		case quote:
+			return i
+		}
+	}
+	return len(text) - 1
+}
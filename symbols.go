@@ -0,0 +1,216 @@
+package glisp
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/conneroisu/glisp/domain"
+	"github.com/conneroisu/glisp/fuzzy"
+)
+
+// DocumentSymbols returns the symbols of the document at uri as the result
+// of a document symbol request.
+//
+// The symbols are flattened into symbol information if the client does not
+// support hierarchical document symbols.
+func DocumentSymbols(
+	caps domain.ClientCapabilities,
+	uri string,
+	symbols []domain.DocumentSymbol,
+) interface{} {
+	if caps.TextDocument.DocumentSymbol.HierarchicalDocumentSymbolSupport {
+		if symbols == nil {
+			return []domain.DocumentSymbol{}
+		}
+		return symbols
+	}
+	return domain.FlattenDocumentSymbols(uri, symbols)
+}
+
+// symbolDataKey is the key of the data envelope of the workspace symbols
+// handed out by a symbol table.
+const symbolDataKey = "workspaceSymbol"
+
+// SymbolTable indexes the symbols of the documents of a workspace to
+// answer workspace symbol requests.
+type SymbolTable struct {
+	mu         sync.RWMutex
+	docs       map[string]symbolDocument
+	generation int
+}
+
+// symbolDocument are the symbols of a single document.
+type symbolDocument struct {
+	symbols []domain.SymbolInformation
+	// generation identifies the symbols so a symbol handed out before they
+	// were replaced is not resolved against the new ones.
+	generation int
+}
+
+// symbolKey identifies a symbol of a symbol table in the data of the
+// workspace symbols handed out for it.
+type symbolKey struct {
+	URI        string `json:"uri"`
+	Generation int    `json:"generation"`
+	Index      int    `json:"index"`
+}
+
+// NewSymbolTable creates a new empty symbol table.
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{docs: map[string]symbolDocument{}}
+}
+
+// SetDocument replaces the symbols of the document at uri.
+func (t *SymbolTable) SetDocument(uri string, symbols []domain.DocumentSymbol) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.generation++
+	t.docs[uri] = symbolDocument{
+		symbols:    domain.FlattenDocumentSymbols(uri, symbols),
+		generation: t.generation,
+	}
+}
+
+// RemoveDocument removes the symbols of the document at uri.
+func (t *SymbolTable) RemoveDocument(uri string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.docs, uri)
+}
+
+// Search answers a workspace symbol request with the symbols whose name
+// fuzzy matches the query, best matches first.
+//
+// The empty query matches all symbols. At most limit symbols are returned
+// if limit is positive. The ranges of the locations are left to
+// Resolve if the client supports resolving them lazily, in which case the
+// data of the symbols identifies them for Resolve.
+func (t *SymbolTable) Search(
+	caps domain.ClientCapabilities,
+	params domain.WorkspaceSymbolParams,
+	limit int,
+) []domain.WorkspaceSymbol {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	all, keys := t.symbols()
+	names := make([]string, len(all))
+	for i, symbol := range all {
+		names[i] = symbol.Name
+	}
+	lazy := resolvesLocationRange(caps)
+	symbols := []domain.WorkspaceSymbol{}
+	for _, ranked := range fuzzy.Find(params.Query, names) {
+		if limit > 0 && len(symbols) == limit {
+			break
+		}
+		symbol := workspaceSymbol(all[ranked.Index])
+		if lazy {
+			symbol.Location.Range = nil
+			symbol.Data = symbolData(keys[ranked.Index])
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// Resolve answers a workspace symbol resolve request by filling in the
+// range of the location of the symbol.
+//
+// The symbol is identified by the data set by Search. ok is false if the
+// symbol was not handed out by Search or the symbols of its document
+// changed since.
+func (t *SymbolTable) Resolve(
+	symbol domain.WorkspaceSymbol,
+) (resolved domain.WorkspaceSymbol, ok bool) {
+	envelope, inner, ok := unwrapData(symbol.Data)
+	if !ok || envelope != symbolDataKey {
+		return symbol, false
+	}
+	var key symbolKey
+	if err := json.Unmarshal(inner, &key); err != nil {
+		return symbol, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	doc, ok := t.docs[key.URI]
+	if !ok ||
+		doc.generation != key.Generation ||
+		key.Index < 0 ||
+		key.Index >= len(doc.symbols) {
+		return symbol, false
+	}
+	rng := doc.symbols[key.Index].Location.Range
+	symbol.Location.Range = &rng
+	return symbol, true
+}
+
+// symbols returns the symbols of all documents ordered by document uri
+// and their keys.
+//
+// t.mu must be held.
+func (t *SymbolTable) symbols() ([]domain.SymbolInformation, []symbolKey) {
+	uris := make([]string, 0, len(t.docs))
+	for uri := range t.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	var symbols []domain.SymbolInformation
+	var keys []symbolKey
+	for _, uri := range uris {
+		doc := t.docs[uri]
+		symbols = append(symbols, doc.symbols...)
+		for i := range doc.symbols {
+			keys = append(keys, symbolKey{
+				URI:        uri,
+				Generation: doc.generation,
+				Index:      i,
+			})
+		}
+	}
+	return symbols, keys
+}
+
+// symbolData wraps the key of a symbol into the data of its workspace
+// symbol.
+func symbolData(key symbolKey) json.RawMessage {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return nil
+	}
+	data, err := wrapData(symbolDataKey, encoded)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// workspaceSymbol converts symbol information into a workspace symbol.
+func workspaceSymbol(info domain.SymbolInformation) domain.WorkspaceSymbol {
+	rng := info.Location.Range
+	return domain.WorkspaceSymbol{
+		Name:          info.Name,
+		Kind:          info.Kind,
+		Tags:          info.Tags,
+		ContainerName: info.ContainerName,
+		Location: domain.WorkspaceSymbolLocation{
+			URI:   info.Location.URI,
+			Range: &rng,
+		},
+	}
+}
+
+// resolvesLocationRange reports whether the client can resolve the range
+// of the location of workspace symbols lazily.
+func resolvesLocationRange(caps domain.ClientCapabilities) bool {
+	support := caps.Workspace.Symbol.ResolveSupport
+	if support == nil {
+		return false
+	}
+	for _, property := range support.Properties {
+		if property == "location.range" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package glisp
+
+import (
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// testSymbols are the symbols of a document with a type and its method.
+func testSymbols() []domain.DocumentSymbol {
+	return []domain.DocumentSymbol{{
+		Name:  "Server",
+		Kind:  domain.SymbolKindClass,
+		Range: span(0, 0, 10),
+		Children: []domain.DocumentSymbol{{
+			Name:  "Serve",
+			Kind:  domain.SymbolKindMethod,
+			Range: span(1, 1, 6),
+		}},
+	}, {
+		Name:  "main",
+		Kind:  domain.SymbolKindFunction,
+		Range: span(3, 0, 4),
+	}}
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	const uri = "file:///a.go"
+	var hierarchical domain.ClientCapabilities
+	hierarchical.TextDocument.DocumentSymbol.HierarchicalDocumentSymbolSupport = true
+
+	if got, ok := DocumentSymbols(hierarchical, uri, nil).([]domain.DocumentSymbol); !ok || got == nil {
+		t.Errorf("hierarchical result for no symbols = %#v, want an empty list", got)
+	}
+	if got, ok := DocumentSymbols(hierarchical, uri, testSymbols()).([]domain.DocumentSymbol); !ok || len(got) != 2 {
+		t.Errorf("hierarchical result = %#v, want the document symbols", got)
+	}
+	flat, ok := DocumentSymbols(domain.ClientCapabilities{}, uri, testSymbols()).([]domain.SymbolInformation)
+	if !ok {
+		t.Fatalf("flat result is %T, want symbol information", flat)
+	}
+	want := []string{"Server", "Server.Serve", "main"}
+	var got []string
+	for _, info := range flat {
+		name := info.Name
+		if info.ContainerName != "" {
+			name = info.ContainerName + "." + name
+		}
+		got = append(got, name)
+		if info.Location.URI != uri {
+			t.Errorf("%s: uri = %q, want %q", name, info.Location.URI, uri)
+		}
+	}
+	if !equalStrings(got, want) {
+		t.Errorf("flattened = %q, want %q", got, want)
+	}
+}
+
+func TestSymbolTableSearch(t *testing.T) {
+	table := NewSymbolTable()
+	table.SetDocument("file:///b.go", testSymbols())
+	table.SetDocument("file:///a.go", []domain.DocumentSymbol{{
+		Name:  "serveHTTP",
+		Kind:  domain.SymbolKindFunction,
+		Range: span(2, 5, 14),
+	}})
+	var lazy domain.ClientCapabilities
+	lazy.Workspace.Symbol.ResolveSupport = &domain.ResolveSupport{Properties: []string{"location.range"}}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		caps  domain.ClientCapabilities
+		want  []string
+		lazy  bool
+	}{
+		{"empty query", "", 0, domain.ClientCapabilities{}, []string{"main", "Serve", "Server", "serveHTTP"}, false},
+		{"fuzzy", "srv", 0, domain.ClientCapabilities{}, []string{"serveHTTP", "Serve", "Server"}, false},
+		{"limit", "", 2, domain.ClientCapabilities{}, []string{"main", "Serve"}, false},
+		{"no match", "xyz", 0, domain.ClientCapabilities{}, nil, false},
+		{"lazy ranges", "main", 0, lazy, []string{"main"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbols := table.Search(tt.caps, domain.WorkspaceSymbolParams{Query: tt.query}, tt.limit)
+			var got []string
+			for _, symbol := range symbols {
+				got = append(got, symbol.Name)
+				if (symbol.Location.Range == nil) != tt.lazy {
+					t.Errorf("%s: range = %v, want lazy %v", symbol.Name, symbol.Location.Range, tt.lazy)
+				}
+			}
+			if !equalStrings(got, tt.want) {
+				t.Errorf("Search = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSymbolTableResolve(t *testing.T) {
+	table := NewSymbolTable()
+	table.SetDocument("file:///b.go", testSymbols())
+	var lazy domain.ClientCapabilities
+	lazy.Workspace.Symbol.ResolveSupport = &domain.ResolveSupport{Properties: []string{"location.range"}}
+	symbols := table.Search(lazy, domain.WorkspaceSymbolParams{Query: "Serve"}, 1)
+	if len(symbols) != 1 {
+		t.Fatalf("got %d symbols, want 1", len(symbols))
+	}
+
+	resolved, ok := table.Resolve(symbols[0])
+	if !ok || resolved.Location.Range == nil || *resolved.Location.Range != span(1, 1, 6) {
+		t.Errorf("Resolve = %+v, %v, want the range of Serve", resolved.Location.Range, ok)
+	}
+	table.SetDocument("file:///b.go", testSymbols())
+	if _, ok := table.Resolve(symbols[0]); ok {
+		t.Error("resolved a symbol of replaced symbols")
+	}
+	table.RemoveDocument("file:///b.go")
+	if _, ok := table.Resolve(symbols[0]); ok {
+		t.Error("resolved a symbol of a removed document")
+	}
+	if _, ok := table.Resolve(domain.WorkspaceSymbol{Name: "Serve"}); ok {
+		t.Error("resolved a symbol without data")
+	}
+}
+
+func TestSymbolTableResolveDuplicateNames(t *testing.T) {
+	table := NewSymbolTable()
+	table.SetDocument("file:///c.go", []domain.DocumentSymbol{{
+		Name:  "add",
+		Kind:  domain.SymbolKindFunction,
+		Range: span(0, 0, 3),
+	}, {
+		Name:  "add",
+		Kind:  domain.SymbolKindFunction,
+		Range: span(4, 0, 3),
+	}})
+	var lazy domain.ClientCapabilities
+	lazy.Workspace.Symbol.ResolveSupport = &domain.ResolveSupport{Properties: []string{"location.range"}}
+	symbols := table.Search(lazy, domain.WorkspaceSymbolParams{Query: "add"}, 0)
+	if len(symbols) != 2 {
+		t.Fatalf("got %d symbols, want 2", len(symbols))
+	}
+
+	var got []domain.Range
+	for _, symbol := range symbols {
+		resolved, ok := table.Resolve(symbol)
+		if !ok || resolved.Location.Range == nil {
+			t.Fatalf("Resolve = %+v, %v, want a range", resolved.Location.Range, ok)
+		}
+		got = append(got, *resolved.Location.Range)
+	}
+	if got[0] == got[1] {
+		t.Errorf("both symbols resolved to %+v, want their own ranges", got[0])
+	}
+}
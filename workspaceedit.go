@@ -0,0 +1,175 @@
+package glisp
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+// WorkspaceEdit adapts the workspace edit to the capabilities of the
+// client.
+//
+// Edits expressed as document changes are kept if the client supports
+// them, dropping change annotations the client does not support, and are
+// otherwise downgraded to the legacy changes map. An error wrapping
+// ErrUnsupported is returned if the edit contains file operations the
+// client does not support or cannot be expressed as changes.
+//
+// Edits in the changes map of an edit with document changes are merged
+// into the document changes since clients read only one of both. Empty
+// document changes are skipped.
+func WorkspaceEdit(
+	caps domain.ClientCapabilities,
+	edit domain.WorkspaceEdit,
+) (domain.WorkspaceEdit, error) {
+	if len(edit.DocumentChanges) == 0 {
+		return edit, nil
+	}
+	editCaps := caps.Workspace.WorkspaceEdit
+	if editCaps.DocumentChanges {
+		documentChanges := mergeChanges(edit.DocumentChanges, edit.Changes)
+		for _, change := range documentChanges {
+			kind := change.Kind()
+			if kind != "" && !supportsResourceOperation(editCaps, kind) {
+				return domain.WorkspaceEdit{}, fmt.Errorf(
+					"%w: %s file operations", ErrUnsupported, kind)
+			}
+		}
+		adapted := domain.WorkspaceEdit{DocumentChanges: documentChanges}
+		if editCaps.ChangeAnnotationSupport != nil {
+			adapted.ChangeAnnotations = edit.ChangeAnnotations
+		} else {
+			adapted.DocumentChanges = withoutAnnotations(documentChanges)
+		}
+		return adapted, nil
+	}
+	changes := map[string][]domain.TextEdit{}
+	for uri, edits := range edit.Changes {
+		changes[uri] = append([]domain.TextEdit(nil), edits...)
+	}
+	edited := map[string]bool{}
+	for _, change := range edit.DocumentChanges {
+		if kind := change.Kind(); kind != "" {
+			return domain.WorkspaceEdit{}, fmt.Errorf(
+				"%w: %s file operations", ErrUnsupported, kind)
+		}
+		if change.TextDocumentEdit == nil {
+			continue
+		}
+		uri := change.TextDocumentEdit.TextDocument.URI
+		if edited[uri] {
+			// Later edits of a document are relative to the result of the
+			// earlier ones which the changes map cannot express.
+			return domain.WorkspaceEdit{}, fmt.Errorf(
+				"%w: multiple edits of %s", ErrUnsupported, uri)
+		}
+		edited[uri] = true
+		// Edits of the changes map are relative to the original document
+		// like the first edit of the document.
+		for _, edit := range change.TextDocumentEdit.Edits {
+			changes[uri] = append(changes[uri], edit.TextEdit)
+		}
+	}
+	return domain.WorkspaceEdit{Changes: changes}, nil
+}
+
+// mergeChanges returns the document changes with the edits of the changes
+// map added to the first edit of their document, or appended as an edit of
+// any version if the document is not edited otherwise.
+//
+// Empty document changes are dropped.
+func mergeChanges(
+	documentChanges []domain.DocumentChange,
+	changes map[string][]domain.TextEdit,
+) []domain.DocumentChange {
+	pending := make(map[string][]domain.TextEdit, len(changes))
+	for uri, edits := range changes {
+		pending[uri] = edits
+	}
+	merged := make([]domain.DocumentChange, 0, len(documentChanges)+len(pending))
+	for _, change := range documentChanges {
+		if change.Kind() == "" && change.TextDocumentEdit == nil {
+			continue
+		}
+		if change.TextDocumentEdit != nil {
+			uri := change.TextDocumentEdit.TextDocument.URI
+			if edits, ok := pending[uri]; ok {
+				edit := *change.TextDocumentEdit
+				edit.Edits = append(annotated(edits), edit.Edits...)
+				change.TextDocumentEdit = &edit
+				delete(pending, uri)
+			}
+		}
+		merged = append(merged, change)
+	}
+	uris := make([]string, 0, len(pending))
+	for uri := range pending {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		merged = append(merged, domain.DocumentChange{
+			TextDocumentEdit: &domain.TextDocumentEdit{
+				TextDocument: domain.OptionalVersionedTextDocumentIdentifier{URI: uri},
+				Edits:        annotated(pending[uri]),
+			},
+		})
+	}
+	return merged
+}
+
+// annotated converts the text edits to annotated text edits without an
+// annotation.
+func annotated(edits []domain.TextEdit) []domain.AnnotatedTextEdit {
+	converted := make([]domain.AnnotatedTextEdit, len(edits))
+	for i, edit := range edits {
+		converted[i] = domain.AnnotatedTextEdit{TextEdit: edit}
+	}
+	return converted
+}
+
+// supportsResourceOperation reports whether the client supports the file
+// operation in workspace edits.
+func supportsResourceOperation(
+	caps domain.WorkspaceEditClientCapabilities,
+	kind domain.ResourceOperationKind,
+) bool {
+	for _, supported := range caps.ResourceOperations {
+		if supported == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutAnnotations returns a copy of the document changes without their
+// change annotation ids.
+func withoutAnnotations(changes []domain.DocumentChange) []domain.DocumentChange {
+	stripped := make([]domain.DocumentChange, len(changes))
+	for i, change := range changes {
+		switch {
+		case change.TextDocumentEdit != nil:
+			edit := *change.TextDocumentEdit
+			edit.Edits = make([]domain.AnnotatedTextEdit, len(edit.Edits))
+			for j, textEdit := range change.TextDocumentEdit.Edits {
+				edit.Edits[j] = domain.AnnotatedTextEdit{TextEdit: textEdit.TextEdit}
+			}
+			change.TextDocumentEdit = &edit
+		case change.CreateFile != nil:
+			op := *change.CreateFile
+			op.AnnotationID = ""
+			change.CreateFile = &op
+		case change.RenameFile != nil:
+			op := *change.RenameFile
+			op.AnnotationID = ""
+			change.RenameFile = &op
+		case change.DeleteFile != nil:
+			op := *change.DeleteFile
+			op.AnnotationID = ""
+			change.DeleteFile = &op
+		}
+		stripped[i] = change
+	}
+	return stripped
+}
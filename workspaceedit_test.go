@@ -0,0 +1,112 @@
+package glisp
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/conneroisu/glisp/domain"
+)
+
+func TestWorkspaceEdit(t *testing.T) {
+	version := 3
+	textEdit := func(uri string, version *int, text string) domain.DocumentChange {
+		return domain.DocumentChange{TextDocumentEdit: &domain.TextDocumentEdit{
+			TextDocument: domain.OptionalVersionedTextDocumentIdentifier{URI: uri, Version: version},
+			Edits: []domain.AnnotatedTextEdit{{
+				TextEdit:     domain.TextEdit{Range: span(0, 0, 1), NewText: text},
+				AnnotationID: "a",
+			}},
+		}}
+	}
+	var documentChanges, annotations domain.ClientCapabilities
+	documentChanges.Workspace.WorkspaceEdit.DocumentChanges = true
+	annotations.Workspace.WorkspaceEdit.DocumentChanges = true
+	annotations.Workspace.WorkspaceEdit.ChangeAnnotationSupport = &domain.ChangeAnnotationSupport{}
+	tests := []struct {
+		name    string
+		caps    domain.ClientCapabilities
+		edit    domain.WorkspaceEdit
+		want    string
+		wantErr error
+	}{
+		{
+			name: "changes only",
+			edit: domain.WorkspaceEdit{Changes: map[string][]domain.TextEdit{
+				"file:///a": {{Range: span(0, 0, 1), NewText: "x"}},
+			}},
+			want: `{"changes":{"file:///a":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"x"}]}}`,
+		},
+		{
+			name: "downgrade merges changes and skips empty changes",
+			edit: domain.WorkspaceEdit{
+				Changes: map[string][]domain.TextEdit{
+					"file:///b": {{Range: span(0, 0, 1), NewText: "y"}},
+				},
+				DocumentChanges: []domain.DocumentChange{
+					{},
+					textEdit("file:///a", &version, "x"),
+				},
+			},
+			want: `{"changes":{"file:///a":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"x"}],"file:///b":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"y"}]}}`,
+		},
+		{
+			name: "downgrade rejects sequential edits",
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				textEdit("file:///a", nil, "x"),
+				textEdit("file:///a", nil, "y"),
+			}},
+			wantErr: ErrUnsupported,
+		},
+		{
+			name: "downgrade rejects file operations",
+			edit: domain.WorkspaceEdit{DocumentChanges: []domain.DocumentChange{
+				{CreateFile: &domain.CreateFile{URI: "file:///c"}},
+			}},
+			wantErr: ErrUnsupported,
+		},
+		{
+			name: "document changes drop annotations and merge changes",
+			caps: documentChanges,
+			edit: domain.WorkspaceEdit{
+				Changes: map[string][]domain.TextEdit{
+					"file:///b": {{Range: span(0, 0, 1), NewText: "y"}},
+				},
+				DocumentChanges: []domain.DocumentChange{
+					textEdit("file:///a", &version, "x"),
+				},
+				ChangeAnnotations: map[string]domain.ChangeAnnotation{"a": {Label: "rename"}},
+			},
+			want: `{"documentChanges":[{"textDocument":{"uri":"file:///a","version":3},"edits":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"x"}]},{"textDocument":{"uri":"file:///b","version":null},"edits":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"y"}]}]}`,
+		},
+		{
+			name: "document changes keep supported annotations",
+			caps: annotations,
+			edit: domain.WorkspaceEdit{
+				DocumentChanges: []domain.DocumentChange{
+					textEdit("file:///a", &version, "x"),
+				},
+				ChangeAnnotations: map[string]domain.ChangeAnnotation{"a": {Label: "rename"}},
+			},
+			want: `{"documentChanges":[{"textDocument":{"uri":"file:///a","version":3},"edits":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"newText":"x","annotationId":"a"}]}],"changeAnnotations":{"a":{"label":"rename"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkspaceEdit(tt.caps, tt.edit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("edit =\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}